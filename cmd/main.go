@@ -4,6 +4,7 @@ import (
 	"net"
 
 	"github.com/PharmaKart/product-svc/internal/handlers"
+	"github.com/PharmaKart/product-svc/internal/jobs"
 	pb "github.com/PharmaKart/product-svc/internal/proto"
 	"github.com/PharmaKart/product-svc/internal/repositories"
 	"github.com/PharmaKart/product-svc/pkg/config"
@@ -30,9 +31,61 @@ func main() {
 	//Initialize repositories
 	productrepo := repositories.NewProductRepository(db)
 	inventorylogrepo := repositories.NewInventoryLogRepository(db)
+	productvariantrepo := repositories.NewProductVariantRepository(db)
+	productidentifierrepo := repositories.NewProductIdentifierRepository(db)
+	brandrepo := repositories.NewBrandRepository(db)
+	manufacturerrepo := repositories.NewManufacturerRepository(db)
+	ingredientrepo := repositories.NewIngredientRepository(db)
+	interactionrepo := repositories.NewInteractionRepository(db)
+	productimagerepo := repositories.NewProductImageRepository(db)
+	recallrepo := repositories.NewRecallRepository(db)
+	productversionrepo := repositories.NewProductVersionRepository(db)
+	producttranslationrepo := repositories.NewProductTranslationRepository(db)
+	productrelationrepo := repositories.NewProductRelationRepository(db)
+	bundlerepo := repositories.NewBundleRepository(db)
+	regionrulerepo := repositories.NewRegionRuleRepository(db)
+	pricelistrepo := repositories.NewPriceListRepository(db)
+	pricechangerepo := repositories.NewPriceChangeRepository(db)
+	promotionrepo := repositories.NewPromotionRepository(db)
+	pricetierrepo := repositories.NewPriceTierRepository(db)
+	taxraterepo := repositories.NewTaxRateRepository(db)
+	marginrulerepo := repositories.NewMarginRuleRepository(db)
+
+	// Classify products created before prescription classes were introduced
+	if _, err := productrepo.BackfillPrescriptionClasses(); err != nil {
+		utils.Logger.Fatal("Failed to backfill prescription classes", map[string]interface{}{
+			"error": err,
+		})
+	}
+
+	// Move the single image of products created before they had ordered images
+	if _, err := productimagerepo.BackfillFromImageURL(); err != nil {
+		utils.Logger.Fatal("Failed to backfill product images", map[string]interface{}{
+			"error": err,
+		})
+	}
+
+	// Convert decimal prices stored before prices were kept in minor units
+	if _, err := productrepo.BackfillPriceMinorUnits(); err != nil {
+		utils.Logger.Fatal("Failed to backfill price minor units", map[string]interface{}{
+			"error": err,
+		})
+	}
+
+	// Record the current prices of products priced before price history was kept
+	if _, err := pricechangerepo.BackfillHistory(); err != nil {
+		utils.Logger.Fatal("Failed to backfill price history", map[string]interface{}{
+			"error": err,
+		})
+	}
+
+	// Start background jobs
+	jobs.StartProductPurge(productrepo, cfg.ProductRetention, cfg.PurgeInterval)
+	jobs.BackfillProductSlugs(productrepo)
+	jobs.StartScheduledPriceChanges(pricechangerepo, cfg.PriceInterval)
 
 	// Initialize handlers
-	productHandler := handlers.NewProductHandler(productrepo, inventorylogrepo)
+	productHandler := handlers.NewProductHandler(productrepo, inventorylogrepo, productvariantrepo, productidentifierrepo, brandrepo, manufacturerrepo, ingredientrepo, interactionrepo, productimagerepo, recallrepo, productversionrepo, producttranslationrepo, productrelationrepo, bundlerepo, regionrulerepo, pricelistrepo, pricechangerepo, promotionrepo, pricetierrepo, taxraterepo, marginrulerepo)
 
 	// Initialize gRPC server
 	lis, err := net.Listen("tcp", ":"+cfg.Port)
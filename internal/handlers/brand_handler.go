@@ -0,0 +1,362 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/PharmaKart/product-svc/internal/models"
+	"github.com/PharmaKart/product-svc/internal/proto"
+	"github.com/PharmaKart/product-svc/pkg/errors"
+	"github.com/PharmaKart/product-svc/pkg/utils"
+	"github.com/google/uuid"
+)
+
+func (h *productHandler) CreateManufacturer(ctx context.Context, req *proto.CreateManufacturerRequest) (*proto.CreateManufacturerResponse, error) {
+	manufacturer := &models.Manufacturer{
+		Name:    req.Manufacturer.Name,
+		Country: &req.Manufacturer.Country,
+		Website: &req.Manufacturer.Website,
+	}
+
+	manufacturerID, err := h.BrandService.CreateManufacturer(manufacturer)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.CreateManufacturerResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.CreateManufacturerResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	return &proto.CreateManufacturerResponse{
+		Success: true,
+		Id:      manufacturerID,
+	}, nil
+}
+
+func (h *productHandler) GetManufacturer(ctx context.Context, req *proto.GetManufacturerRequest) (*proto.GetManufacturerResponse, error) {
+	manufacturer, err := h.BrandService.GetManufacturer(req.ManufacturerId)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.GetManufacturerResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.GetManufacturerResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	return &proto.GetManufacturerResponse{
+		Success:      true,
+		Manufacturer: toProtoManufacturer(manufacturer),
+	}, nil
+}
+
+func (h *productHandler) ListManufacturers(ctx context.Context, req *proto.ListManufacturersRequest) (*proto.ListManufacturersResponse, error) {
+	manufacturers, total, err := h.BrandService.ListManufacturers(req.Page, req.Limit)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.ListManufacturersResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.ListManufacturersResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	var pbManufacturers []*proto.Manufacturer
+	for _, manufacturer := range manufacturers {
+		pbManufacturers = append(pbManufacturers, toProtoManufacturer(&manufacturer))
+	}
+
+	return &proto.ListManufacturersResponse{
+		Success:       true,
+		Manufacturers: pbManufacturers,
+		Total:         total,
+		Page:          req.Page,
+		Limit:         req.Limit,
+	}, nil
+}
+
+func (h *productHandler) UpdateManufacturer(ctx context.Context, req *proto.UpdateManufacturerRequest) (*proto.UpdateManufacturerResponse, error) {
+	err := h.BrandService.UpdateManufacturer(req.ManufacturerId, req.Manufacturer.Name, req.Manufacturer.Country, req.Manufacturer.Website)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.UpdateManufacturerResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.UpdateManufacturerResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	return &proto.UpdateManufacturerResponse{
+		Success: true,
+		Message: "Manufacturer updated successfully",
+	}, nil
+}
+
+func (h *productHandler) DeleteManufacturer(ctx context.Context, req *proto.DeleteManufacturerRequest) (*proto.DeleteManufacturerResponse, error) {
+	err := h.BrandService.DeleteManufacturer(req.ManufacturerId)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.DeleteManufacturerResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.DeleteManufacturerResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	return &proto.DeleteManufacturerResponse{
+		Success: true,
+		Message: "Manufacturer deleted successfully",
+	}, nil
+}
+
+func (h *productHandler) CreateBrand(ctx context.Context, req *proto.CreateBrandRequest) (*proto.CreateBrandResponse, error) {
+	manufacturerId, err := uuid.Parse(req.Brand.ManufacturerId)
+	if err != nil {
+		return &proto.CreateBrandResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.ValidationError),
+				Message: "Invalid manufacturer ID",
+				Details: utils.ConvertMapToKeyValuePairs(map[string]string{"manufacturerId": fmt.Sprintf("Invalid UUID: %s", req.Brand.ManufacturerId)}),
+			},
+		}, nil
+	}
+
+	brand := &models.Brand{
+		ManufacturerID: manufacturerId,
+		Name:           req.Brand.Name,
+	}
+
+	brandID, err := h.BrandService.CreateBrand(brand)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.CreateBrandResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.CreateBrandResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	return &proto.CreateBrandResponse{
+		Success: true,
+		Id:      brandID,
+	}, nil
+}
+
+func (h *productHandler) GetBrand(ctx context.Context, req *proto.GetBrandRequest) (*proto.GetBrandResponse, error) {
+	brand, err := h.BrandService.GetBrand(req.BrandId)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.GetBrandResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.GetBrandResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	return &proto.GetBrandResponse{
+		Success: true,
+		Brand:   toProtoBrand(brand),
+	}, nil
+}
+
+func (h *productHandler) ListBrands(ctx context.Context, req *proto.ListBrandsRequest) (*proto.ListBrandsResponse, error) {
+	brands, total, err := h.BrandService.ListBrands(req.ManufacturerId, req.Page, req.Limit)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.ListBrandsResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.ListBrandsResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	var pbBrands []*proto.Brand
+	for _, brand := range brands {
+		pbBrands = append(pbBrands, toProtoBrand(&brand))
+	}
+
+	return &proto.ListBrandsResponse{
+		Success: true,
+		Brands:  pbBrands,
+		Total:   total,
+		Page:    req.Page,
+		Limit:   req.Limit,
+	}, nil
+}
+
+func (h *productHandler) UpdateBrand(ctx context.Context, req *proto.UpdateBrandRequest) (*proto.UpdateBrandResponse, error) {
+	err := h.BrandService.UpdateBrand(req.BrandId, req.Brand.ManufacturerId, req.Brand.Name)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.UpdateBrandResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.UpdateBrandResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	return &proto.UpdateBrandResponse{
+		Success: true,
+		Message: "Brand updated successfully",
+	}, nil
+}
+
+func (h *productHandler) DeleteBrand(ctx context.Context, req *proto.DeleteBrandRequest) (*proto.DeleteBrandResponse, error) {
+	err := h.BrandService.DeleteBrand(req.BrandId)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.DeleteBrandResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.DeleteBrandResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	return &proto.DeleteBrandResponse{
+		Success: true,
+		Message: "Brand deleted successfully",
+	}, nil
+}
+
+func toProtoManufacturer(manufacturer *models.Manufacturer) *proto.Manufacturer {
+	pbManufacturer := &proto.Manufacturer{
+		Id:   manufacturer.ID.String(),
+		Name: manufacturer.Name,
+	}
+
+	if manufacturer.Country != nil {
+		pbManufacturer.Country = *manufacturer.Country
+	}
+
+	if manufacturer.Website != nil {
+		pbManufacturer.Website = *manufacturer.Website
+	}
+
+	return pbManufacturer
+}
+
+func toProtoBrand(brand *models.Brand) *proto.Brand {
+	pbBrand := &proto.Brand{
+		Id:             brand.ID.String(),
+		ManufacturerId: brand.ManufacturerID.String(),
+		Name:           brand.Name,
+	}
+
+	if brand.Manufacturer != nil {
+		pbBrand.ManufacturerName = brand.Manufacturer.Name
+	}
+
+	return pbBrand
+}
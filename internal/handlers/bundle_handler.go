@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/PharmaKart/product-svc/internal/models"
+	"github.com/PharmaKart/product-svc/internal/proto"
+	"github.com/PharmaKart/product-svc/pkg/errors"
+	"github.com/PharmaKart/product-svc/pkg/utils"
+	"github.com/google/uuid"
+)
+
+func (h *productHandler) SetBundleComponents(ctx context.Context, req *proto.SetBundleComponentsRequest) (*proto.SetBundleComponentsResponse, error) {
+	var components []models.BundleComponent
+	for _, pbComponent := range req.Components {
+		componentId, err := uuid.Parse(pbComponent.ComponentId)
+		if err != nil {
+			return &proto.SetBundleComponentsResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(errors.ValidationError),
+					Message: "Invalid component ID",
+					Details: utils.ConvertMapToKeyValuePairs(map[string]string{"componentId": fmt.Sprintf("Invalid UUID: %s", pbComponent.ComponentId)}),
+				},
+			}, nil
+		}
+
+		component := models.BundleComponent{
+			ComponentID: componentId,
+			Quantity:    int(pbComponent.Quantity),
+		}
+
+		if pbComponent.VariantId != "" {
+			variantId, err := uuid.Parse(pbComponent.VariantId)
+			if err != nil {
+				return &proto.SetBundleComponentsResponse{
+					Success: false,
+					Error: &proto.Error{
+						Type:    string(errors.ValidationError),
+						Message: "Invalid variant ID",
+						Details: utils.ConvertMapToKeyValuePairs(map[string]string{"variantId": fmt.Sprintf("Invalid UUID: %s", pbComponent.VariantId)}),
+					},
+				}, nil
+			}
+			component.VariantID = &variantId
+		}
+
+		components = append(components, component)
+	}
+
+	err := h.ProductService.SetBundleComponents(req.BundleId, components)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.SetBundleComponentsResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.SetBundleComponentsResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	return &proto.SetBundleComponentsResponse{
+		Success: true,
+		Message: "Bundle components updated successfully",
+	}, nil
+}
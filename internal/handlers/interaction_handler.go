@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/PharmaKart/product-svc/internal/proto"
+	"github.com/PharmaKart/product-svc/pkg/errors"
+	"github.com/PharmaKart/product-svc/pkg/utils"
+)
+
+func (h *productHandler) ImportInteractions(ctx context.Context, req *proto.ImportInteractionsRequest) (*proto.ImportInteractionsResponse, error) {
+	imported, err := h.InteractionService.ImportInteractions(req.Data, req.Replace)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.ImportInteractionsResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.ImportInteractionsResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	return &proto.ImportInteractionsResponse{
+		Success:  true,
+		Imported: int32(imported),
+	}, nil
+}
+
+func (h *productHandler) CheckInteractions(ctx context.Context, req *proto.CheckInteractionsRequest) (*proto.CheckInteractionsResponse, error) {
+	interactions, err := h.InteractionService.CheckInteractions(req.ProductIds)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.CheckInteractionsResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.CheckInteractionsResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	var pbInteractions []*proto.DrugInteraction
+	for _, interaction := range interactions {
+		pbInteractions = append(pbInteractions, &proto.DrugInteraction{
+			ProductAId:  interaction.ProductAID.String(),
+			ProductBId:  interaction.ProductBID.String(),
+			IngredientA: interaction.IngredientA,
+			IngredientB: interaction.IngredientB,
+			Severity:    interaction.Severity,
+			Description: interaction.Description,
+		})
+	}
+
+	return &proto.CheckInteractionsResponse{
+		Success:      true,
+		Interactions: pbInteractions,
+	}, nil
+}
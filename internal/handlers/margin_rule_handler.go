@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/PharmaKart/product-svc/internal/models"
+	"github.com/PharmaKart/product-svc/internal/proto"
+	"github.com/PharmaKart/product-svc/pkg/errors"
+	"github.com/PharmaKart/product-svc/pkg/utils"
+	"github.com/google/uuid"
+)
+
+func (h *productHandler) SetMarginRule(ctx context.Context, req *proto.SetMarginRuleRequest) (*proto.SetMarginRuleResponse, error) {
+	if req.MarginRule == nil {
+		return &proto.SetMarginRuleResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.ValidationError),
+				Message: "Margin rule is required",
+			},
+		}, nil
+	}
+
+	rule := &models.MarginRule{
+		TargetType:           req.MarginRule.TargetType,
+		MinMarginBasisPoints: int(req.MarginRule.MinMarginBasisPoints),
+		Enforcement:          req.MarginRule.Enforcement,
+	}
+
+	if req.MarginRule.TargetId != "" {
+		targetId, err := uuid.Parse(req.MarginRule.TargetId)
+		if err != nil {
+			return &proto.SetMarginRuleResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(errors.ValidationError),
+					Message: "Invalid target ID",
+					Details: utils.ConvertMapToKeyValuePairs(map[string]string{"targetId": fmt.Sprintf("Invalid UUID: %s", req.MarginRule.TargetId)}),
+				},
+			}, nil
+		}
+		rule.TargetID = &targetId
+	}
+
+	err := h.ProductService.SetMarginRule(rule)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.SetMarginRuleResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.SetMarginRuleResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	return &proto.SetMarginRuleResponse{
+		Success: true,
+		Message: "Margin rule saved successfully",
+	}, nil
+}
+
+func (h *productHandler) RemoveMarginRule(ctx context.Context, req *proto.RemoveMarginRuleRequest) (*proto.RemoveMarginRuleResponse, error) {
+	err := h.ProductService.RemoveMarginRule(req.MarginRuleId)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.RemoveMarginRuleResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.RemoveMarginRuleResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	return &proto.RemoveMarginRuleResponse{
+		Success: true,
+		Message: "Margin rule removed successfully",
+	}, nil
+}
+
+func (h *productHandler) ListMarginRules(ctx context.Context, req *proto.ListMarginRulesRequest) (*proto.ListMarginRulesResponse, error) {
+	rules, err := h.ProductService.ListMarginRules()
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.ListMarginRulesResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.ListMarginRulesResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	var pbRules []*proto.MarginRule
+	for _, rule := range rules {
+		pbRule := &proto.MarginRule{
+			Id:                   rule.ID.String(),
+			TargetType:           rule.TargetType,
+			MinMarginBasisPoints: int32(rule.MinMarginBasisPoints),
+			Enforcement:          rule.Enforcement,
+			UpdatedAt:            rule.UpdatedAt.String(),
+		}
+		if rule.TargetID != nil {
+			pbRule.TargetId = rule.TargetID.String()
+		}
+		pbRules = append(pbRules, pbRule)
+	}
+
+	return &proto.ListMarginRulesResponse{
+		Success:     true,
+		MarginRules: pbRules,
+	}, nil
+}
@@ -0,0 +1,236 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/PharmaKart/product-svc/internal/models"
+	"github.com/PharmaKart/product-svc/internal/proto"
+	"github.com/PharmaKart/product-svc/pkg/errors"
+	"github.com/PharmaKart/product-svc/pkg/utils"
+	"github.com/google/uuid"
+)
+
+func (h *productHandler) SchedulePriceChange(ctx context.Context, req *proto.SchedulePriceChangeRequest) (*proto.SchedulePriceChangeResponse, error) {
+	productId, err := uuid.Parse(req.ProductId)
+	if err != nil {
+		return &proto.SchedulePriceChangeResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.ValidationError),
+				Message: "Invalid product ID",
+				Details: utils.ConvertMapToKeyValuePairs(map[string]string{"productId": fmt.Sprintf("Invalid UUID: %s", req.ProductId)}),
+			},
+		}, nil
+	}
+
+	effectiveAt, err := time.Parse(time.RFC3339, req.EffectiveAt)
+	if err != nil {
+		return &proto.SchedulePriceChangeResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.ValidationError),
+				Message: "Invalid date",
+				Details: utils.ConvertMapToKeyValuePairs(map[string]string{"effectiveAt": fmt.Sprintf("Invalid RFC 3339 date: %s", req.EffectiveAt)}),
+			},
+		}, nil
+	}
+
+	change := &models.PriceChange{
+		ProductID:   productId,
+		EffectiveAt: effectiveAt,
+	}
+
+	if req.Price != nil {
+		change.PriceMinor = req.Price.Amount
+		change.Currency = req.Price.Currency
+	}
+
+	if req.VariantId != "" {
+		variantId, err := uuid.Parse(req.VariantId)
+		if err != nil {
+			return &proto.SchedulePriceChangeResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(errors.ValidationError),
+					Message: "Invalid variant ID",
+					Details: utils.ConvertMapToKeyValuePairs(map[string]string{"variantId": fmt.Sprintf("Invalid UUID: %s", req.VariantId)}),
+				},
+			}, nil
+		}
+		change.VariantID = &variantId
+	}
+
+	if req.OverrideMargin && !utils.IsAdmin(ctx) {
+		return &proto.SchedulePriceChangeResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.AuthError),
+				Message: "Only admins can override margin rules",
+			},
+		}, nil
+	}
+
+	changeID, err := h.ProductService.SchedulePriceChange(change, req.OverrideMargin, utils.GetActor(ctx))
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.SchedulePriceChangeResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.SchedulePriceChangeResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	return &proto.SchedulePriceChangeResponse{
+		Success: true,
+		Id:      changeID,
+	}, nil
+}
+
+func (h *productHandler) CancelPriceChange(ctx context.Context, req *proto.CancelPriceChangeRequest) (*proto.CancelPriceChangeResponse, error) {
+	err := h.ProductService.CancelPriceChange(req.PriceChangeId)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.CancelPriceChangeResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.CancelPriceChangeResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	return &proto.CancelPriceChangeResponse{
+		Success: true,
+		Message: "Price change cancelled successfully",
+	}, nil
+}
+
+func (h *productHandler) GetPriceHistory(ctx context.Context, req *proto.GetPriceHistoryRequest) (*proto.GetPriceHistoryResponse, error) {
+	var variantID *uuid.UUID
+	if req.VariantId != "" {
+		variantId, err := uuid.Parse(req.VariantId)
+		if err != nil {
+			return &proto.GetPriceHistoryResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(errors.ValidationError),
+					Message: "Invalid variant ID",
+					Details: utils.ConvertMapToKeyValuePairs(map[string]string{"variantId": fmt.Sprintf("Invalid UUID: %s", req.VariantId)}),
+				},
+			}, nil
+		}
+		variantID = &variantId
+	}
+
+	changes, total, err := h.ProductService.GetPriceHistory(req.ProductId, variantID, req.IncludeScheduled, req.Page, req.Limit)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.GetPriceHistoryResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.GetPriceHistoryResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	var pbChanges []*proto.PriceChange
+	for _, change := range changes {
+		pbChanges = append(pbChanges, toProtoPriceChange(&change))
+	}
+
+	response := &proto.GetPriceHistoryResponse{
+		Success: true,
+		Changes: pbChanges,
+		Total:   total,
+		Page:    req.Page,
+		Limit:   req.Limit,
+	}
+
+	if req.At != "" {
+		at, err := time.Parse(time.RFC3339, req.At)
+		if err != nil {
+			return &proto.GetPriceHistoryResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(errors.ValidationError),
+					Message: "Invalid date",
+					Details: utils.ConvertMapToKeyValuePairs(map[string]string{"at": fmt.Sprintf("Invalid RFC 3339 date: %s", req.At)}),
+				},
+			}, nil
+		}
+
+		priceAt, err := h.ProductService.GetPriceAt(req.ProductId, variantID, at)
+		if err != nil {
+			if appErr, ok := errors.IsAppError(err); ok {
+				return &proto.GetPriceHistoryResponse{
+					Success: false,
+					Error: &proto.Error{
+						Type:    string(appErr.Type),
+						Message: appErr.Message,
+						Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+					},
+				}, nil
+			}
+			return &proto.GetPriceHistoryResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(errors.InternalError),
+					Message: "An unexpected error occurred",
+				},
+			}, nil
+		}
+		response.PriceAt = toProtoPriceChange(priceAt)
+	}
+
+	return response, nil
+}
+
+func toProtoPriceChange(change *models.PriceChange) *proto.PriceChange {
+	pbChange := &proto.PriceChange{
+		Id:          change.ID.String(),
+		ProductId:   change.ProductID.String(),
+		Price:       toProtoMoney(change.Price()),
+		EffectiveAt: change.EffectiveAt.String(),
+		Status:      change.Status,
+		Actor:       change.Actor,
+		CreatedAt:   change.CreatedAt.String(),
+	}
+
+	if change.VariantID != nil {
+		pbChange.VariantId = change.VariantID.String()
+	}
+
+	return pbChange
+}
@@ -0,0 +1,270 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/PharmaKart/product-svc/internal/models"
+	"github.com/PharmaKart/product-svc/internal/proto"
+	"github.com/PharmaKart/product-svc/pkg/errors"
+	"github.com/PharmaKart/product-svc/pkg/utils"
+	"github.com/google/uuid"
+)
+
+func (h *productHandler) CreatePriceList(ctx context.Context, req *proto.CreatePriceListRequest) (*proto.CreatePriceListResponse, error) {
+	if req.PriceList == nil {
+		return &proto.CreatePriceListResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.ValidationError),
+				Message: "Price list is required",
+			},
+		}, nil
+	}
+
+	priceList := &models.PriceList{
+		Name:      req.PriceList.Name,
+		Currency:  req.PriceList.Currency,
+		Channel:   req.PriceList.Channel,
+		IsDefault: req.PriceList.IsDefault,
+	}
+
+	priceListID, err := h.PriceListService.CreatePriceList(priceList)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.CreatePriceListResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.CreatePriceListResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	return &proto.CreatePriceListResponse{
+		Success: true,
+		Id:      priceListID,
+	}, nil
+}
+
+func (h *productHandler) ListPriceLists(ctx context.Context, req *proto.ListPriceListsRequest) (*proto.ListPriceListsResponse, error) {
+	priceLists, err := h.PriceListService.ListPriceLists(req.Currency, req.Channel)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.ListPriceListsResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.ListPriceListsResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	var pbPriceLists []*proto.PriceList
+	for _, priceList := range priceLists {
+		pbPriceLists = append(pbPriceLists, &proto.PriceList{
+			Id:        priceList.ID.String(),
+			Name:      priceList.Name,
+			Currency:  priceList.Currency,
+			Channel:   priceList.Channel,
+			IsDefault: priceList.IsDefault,
+		})
+	}
+
+	return &proto.ListPriceListsResponse{
+		Success:    true,
+		PriceLists: pbPriceLists,
+	}, nil
+}
+
+func (h *productHandler) DeletePriceList(ctx context.Context, req *proto.DeletePriceListRequest) (*proto.DeletePriceListResponse, error) {
+	err := h.PriceListService.DeletePriceList(req.PriceListId)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.DeletePriceListResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.DeletePriceListResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	return &proto.DeletePriceListResponse{
+		Success: true,
+		Message: "Price list deleted successfully",
+	}, nil
+}
+
+func (h *productHandler) SetPriceListPrices(ctx context.Context, req *proto.SetPriceListPricesRequest) (*proto.SetPriceListPricesResponse, error) {
+	var items []models.PriceListItem
+	for _, pbPrice := range req.Prices {
+		productId, err := uuid.Parse(pbPrice.ProductId)
+		if err != nil {
+			return &proto.SetPriceListPricesResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(errors.ValidationError),
+					Message: "Invalid product ID",
+					Details: utils.ConvertMapToKeyValuePairs(map[string]string{"productId": fmt.Sprintf("Invalid UUID: %s", pbPrice.ProductId)}),
+				},
+			}, nil
+		}
+
+		item := models.PriceListItem{ProductID: productId}
+		if pbPrice.Price != nil {
+			item.PriceMinor = pbPrice.Price.Amount
+			item.Currency = pbPrice.Price.Currency
+		}
+		items = append(items, item)
+	}
+
+	err := h.PriceListService.SetPrices(req.PriceListId, items)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.SetPriceListPricesResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.SetPriceListPricesResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	return &proto.SetPriceListPricesResponse{
+		Success: true,
+		Message: "Prices saved successfully",
+	}, nil
+}
+
+func (h *productHandler) RemovePriceListPrice(ctx context.Context, req *proto.RemovePriceListPriceRequest) (*proto.RemovePriceListPriceResponse, error) {
+	err := h.PriceListService.RemovePrice(req.PriceListId, req.ProductId)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.RemovePriceListPriceResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.RemovePriceListPriceResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	return &proto.RemovePriceListPriceResponse{
+		Success: true,
+		Message: "Price removed successfully",
+	}, nil
+}
+
+func (h *productHandler) ListPriceListPrices(ctx context.Context, req *proto.ListPriceListPricesRequest) (*proto.ListPriceListPricesResponse, error) {
+	items, total, err := h.PriceListService.ListPrices(req.PriceListId, req.Page, req.Limit)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.ListPriceListPricesResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.ListPriceListPricesResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	var pbPrices []*proto.PriceListPrice
+	for _, item := range items {
+		pbPrice := &proto.PriceListPrice{
+			ProductId: item.ProductID.String(),
+			Price:     toProtoMoney(models.Money{Amount: item.PriceMinor, Currency: item.Currency}),
+		}
+		if item.Product != nil {
+			pbPrice.ProductName = item.Product.Name
+		}
+		pbPrices = append(pbPrices, pbPrice)
+	}
+
+	return &proto.ListPriceListPricesResponse{
+		Success: true,
+		Prices:  pbPrices,
+		Total:   total,
+		Page:    req.Page,
+		Limit:   req.Limit,
+	}, nil
+}
+
+// toModelPriceContext converts an optional price context, an unset one keeps the base prices
+func toModelPriceContext(pbPricing *proto.PriceContext) models.PriceContext {
+	if pbPricing == nil {
+		return models.PriceContext{}
+	}
+
+	return models.PriceContext{
+		PriceListID: pbPricing.PriceListId,
+		Currency:    pbPricing.Currency,
+		Channel:     pbPricing.Channel,
+	}
+}
+
+func toProtoAppliedPrice(price *models.AppliedPrice) *proto.AppliedPrice {
+	pbPrice := &proto.AppliedPrice{
+		Price: toProtoMoney(price.Price),
+	}
+
+	if price.PriceListID != nil {
+		pbPrice.PriceListId = price.PriceListID.String()
+	}
+
+	return pbPrice
+}
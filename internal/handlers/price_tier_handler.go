@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/PharmaKart/product-svc/internal/models"
+	"github.com/PharmaKart/product-svc/internal/proto"
+	"github.com/PharmaKart/product-svc/pkg/errors"
+	"github.com/PharmaKart/product-svc/pkg/utils"
+)
+
+func (h *productHandler) SetPriceTiers(ctx context.Context, req *proto.SetPriceTiersRequest) (*proto.SetPriceTiersResponse, error) {
+	var tiers []models.PriceTier
+	for i, tier := range req.Tiers {
+		if tier.Price == nil {
+			return &proto.SetPriceTiersResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(errors.ValidationError),
+					Message: "Price is required",
+					Details: utils.ConvertMapToKeyValuePairs(map[string]string{fmt.Sprintf("tiers[%d]", i): "Price is required"}),
+				},
+			}, nil
+		}
+
+		tiers = append(tiers, models.PriceTier{
+			MinQuantity: int(tier.MinQuantity),
+			PriceMinor:  tier.Price.Amount,
+			Currency:    tier.Price.Currency,
+		})
+	}
+
+	if req.OverrideMargin && !utils.IsAdmin(ctx) {
+		return &proto.SetPriceTiersResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.AuthError),
+				Message: "Only admins can override margin rules",
+			},
+		}, nil
+	}
+
+	err := h.ProductService.SetPriceTiers(req.ProductId, tiers, req.OverrideMargin)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.SetPriceTiersResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.SetPriceTiersResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	return &proto.SetPriceTiersResponse{
+		Success: true,
+		Message: "Price tiers saved successfully",
+	}, nil
+}
+
+func toProtoPriceTier(tier *models.PriceTier, currency string) *proto.PriceTier {
+	return &proto.PriceTier{
+		MinQuantity: int32(tier.MinQuantity),
+		Price:       toProtoMoney(models.Money{Amount: tier.PriceMinor, Currency: currency}),
+	}
+}
@@ -3,6 +3,7 @@ package handlers
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/PharmaKart/product-svc/internal/models"
 	"github.com/PharmaKart/product-svc/internal/proto"
@@ -19,32 +20,128 @@ type ProductHandler interface {
 	ListProducts(ctx context.Context, req *proto.ListProductsRequest) (*proto.ListProductsResponse, error)
 	UpdateProduct(ctx context.Context, req *proto.UpdateProductRequest) (*proto.UpdateProductResponse, error)
 	DeleteProduct(ctx context.Context, req *proto.DeleteProductRequest) (*proto.DeleteProductResponse, error)
+	RestoreProduct(ctx context.Context, req *proto.RestoreProductRequest) (*proto.RestoreProductResponse, error)
+	UpdateProductStatus(ctx context.Context, req *proto.UpdateProductStatusRequest) (*proto.UpdateProductStatusResponse, error)
 	UpdateStock(ctx context.Context, req *proto.UpdateStockRequest) (*proto.UpdateStockResponse, error)
 	GetInventoryLogs(ctx context.Context, req *proto.GetInventoryLogsRequest) (*proto.GetInventoryLogsResponse, error)
+	AddProductVariant(ctx context.Context, req *proto.AddProductVariantRequest) (*proto.AddProductVariantResponse, error)
+	UpdateProductVariant(ctx context.Context, req *proto.UpdateProductVariantRequest) (*proto.UpdateProductVariantResponse, error)
+	DeleteProductVariant(ctx context.Context, req *proto.DeleteProductVariantRequest) (*proto.DeleteProductVariantResponse, error)
+	AddProductIdentifier(ctx context.Context, req *proto.AddProductIdentifierRequest) (*proto.AddProductIdentifierResponse, error)
+	RemoveProductIdentifier(ctx context.Context, req *proto.RemoveProductIdentifierRequest) (*proto.RemoveProductIdentifierResponse, error)
+	GetProductByIdentifier(ctx context.Context, req *proto.GetProductByIdentifierRequest) (*proto.GetProductByIdentifierResponse, error)
+	GetProductBySlug(ctx context.Context, req *proto.GetProductBySlugRequest) (*proto.GetProductBySlugResponse, error)
+	SetProductTranslation(ctx context.Context, req *proto.SetProductTranslationRequest) (*proto.SetProductTranslationResponse, error)
+	RemoveProductTranslation(ctx context.Context, req *proto.RemoveProductTranslationRequest) (*proto.RemoveProductTranslationResponse, error)
+	ListProductTranslations(ctx context.Context, req *proto.ListProductTranslationsRequest) (*proto.ListProductTranslationsResponse, error)
+	AddProductRelation(ctx context.Context, req *proto.AddProductRelationRequest) (*proto.AddProductRelationResponse, error)
+	RemoveProductRelation(ctx context.Context, req *proto.RemoveProductRelationRequest) (*proto.RemoveProductRelationResponse, error)
+	ListProductRelations(ctx context.Context, req *proto.ListProductRelationsRequest) (*proto.ListProductRelationsResponse, error)
+	SetBundleComponents(ctx context.Context, req *proto.SetBundleComponentsRequest) (*proto.SetBundleComponentsResponse, error)
+	SetProductRegionRule(ctx context.Context, req *proto.SetProductRegionRuleRequest) (*proto.SetProductRegionRuleResponse, error)
+	RemoveProductRegionRule(ctx context.Context, req *proto.RemoveProductRegionRuleRequest) (*proto.RemoveProductRegionRuleResponse, error)
+	ListProductRegionRules(ctx context.Context, req *proto.ListProductRegionRulesRequest) (*proto.ListProductRegionRulesResponse, error)
+	GetProductAvailabilityForRegion(ctx context.Context, req *proto.GetProductAvailabilityForRegionRequest) (*proto.GetProductAvailabilityForRegionResponse, error)
+	CreatePriceList(ctx context.Context, req *proto.CreatePriceListRequest) (*proto.CreatePriceListResponse, error)
+	ListPriceLists(ctx context.Context, req *proto.ListPriceListsRequest) (*proto.ListPriceListsResponse, error)
+	DeletePriceList(ctx context.Context, req *proto.DeletePriceListRequest) (*proto.DeletePriceListResponse, error)
+	SetPriceListPrices(ctx context.Context, req *proto.SetPriceListPricesRequest) (*proto.SetPriceListPricesResponse, error)
+	RemovePriceListPrice(ctx context.Context, req *proto.RemovePriceListPriceRequest) (*proto.RemovePriceListPriceResponse, error)
+	ListPriceListPrices(ctx context.Context, req *proto.ListPriceListPricesRequest) (*proto.ListPriceListPricesResponse, error)
+	CreateManufacturer(ctx context.Context, req *proto.CreateManufacturerRequest) (*proto.CreateManufacturerResponse, error)
+	GetManufacturer(ctx context.Context, req *proto.GetManufacturerRequest) (*proto.GetManufacturerResponse, error)
+	ListManufacturers(ctx context.Context, req *proto.ListManufacturersRequest) (*proto.ListManufacturersResponse, error)
+	UpdateManufacturer(ctx context.Context, req *proto.UpdateManufacturerRequest) (*proto.UpdateManufacturerResponse, error)
+	DeleteManufacturer(ctx context.Context, req *proto.DeleteManufacturerRequest) (*proto.DeleteManufacturerResponse, error)
+	CreateBrand(ctx context.Context, req *proto.CreateBrandRequest) (*proto.CreateBrandResponse, error)
+	GetBrand(ctx context.Context, req *proto.GetBrandRequest) (*proto.GetBrandResponse, error)
+	ListBrands(ctx context.Context, req *proto.ListBrandsRequest) (*proto.ListBrandsResponse, error)
+	UpdateBrand(ctx context.Context, req *proto.UpdateBrandRequest) (*proto.UpdateBrandResponse, error)
+	DeleteBrand(ctx context.Context, req *proto.DeleteBrandRequest) (*proto.DeleteBrandResponse, error)
+	SetProductIngredients(ctx context.Context, req *proto.SetProductIngredientsRequest) (*proto.SetProductIngredientsResponse, error)
+	FindEquivalents(ctx context.Context, req *proto.FindEquivalentsRequest) (*proto.FindEquivalentsResponse, error)
+	ImportInteractions(ctx context.Context, req *proto.ImportInteractionsRequest) (*proto.ImportInteractionsResponse, error)
+	CheckInteractions(ctx context.Context, req *proto.CheckInteractionsRequest) (*proto.CheckInteractionsResponse, error)
+	AddProductImage(ctx context.Context, req *proto.AddProductImageRequest) (*proto.AddProductImageResponse, error)
+	ReorderProductImages(ctx context.Context, req *proto.ReorderProductImagesRequest) (*proto.ReorderProductImagesResponse, error)
+	RemoveProductImage(ctx context.Context, req *proto.RemoveProductImageRequest) (*proto.RemoveProductImageResponse, error)
+	CreateRecall(ctx context.Context, req *proto.CreateRecallRequest) (*proto.CreateRecallResponse, error)
+	ResolveRecall(ctx context.Context, req *proto.ResolveRecallRequest) (*proto.ResolveRecallResponse, error)
+	ListRecalls(ctx context.Context, req *proto.ListRecallsRequest) (*proto.ListRecallsResponse, error)
+	GetRecallImpact(ctx context.Context, req *proto.GetRecallImpactRequest) (*proto.GetRecallImpactResponse, error)
+	GetProductHistory(ctx context.Context, req *proto.GetProductHistoryRequest) (*proto.GetProductHistoryResponse, error)
+	GetProductVersion(ctx context.Context, req *proto.GetProductVersionRequest) (*proto.GetProductVersionResponse, error)
+	SchedulePriceChange(ctx context.Context, req *proto.SchedulePriceChangeRequest) (*proto.SchedulePriceChangeResponse, error)
+	CancelPriceChange(ctx context.Context, req *proto.CancelPriceChangeRequest) (*proto.CancelPriceChangeResponse, error)
+	GetPriceHistory(ctx context.Context, req *proto.GetPriceHistoryRequest) (*proto.GetPriceHistoryResponse, error)
+	CreatePromotion(ctx context.Context, req *proto.CreatePromotionRequest) (*proto.CreatePromotionResponse, error)
+	ListPromotions(ctx context.Context, req *proto.ListPromotionsRequest) (*proto.ListPromotionsResponse, error)
+	DeletePromotion(ctx context.Context, req *proto.DeletePromotionRequest) (*proto.DeletePromotionResponse, error)
+	PriceQuote(ctx context.Context, req *proto.PriceQuoteRequest) (*proto.PriceQuoteResponse, error)
+	SetPriceTiers(ctx context.Context, req *proto.SetPriceTiersRequest) (*proto.SetPriceTiersResponse, error)
+	SetTaxRate(ctx context.Context, req *proto.SetTaxRateRequest) (*proto.SetTaxRateResponse, error)
+	RemoveTaxRate(ctx context.Context, req *proto.RemoveTaxRateRequest) (*proto.RemoveTaxRateResponse, error)
+	ListTaxRates(ctx context.Context, req *proto.ListTaxRatesRequest) (*proto.ListTaxRatesResponse, error)
+	CalculateTax(ctx context.Context, req *proto.CalculateTaxRequest) (*proto.CalculateTaxResponse, error)
+	SetMarginRule(ctx context.Context, req *proto.SetMarginRuleRequest) (*proto.SetMarginRuleResponse, error)
+	RemoveMarginRule(ctx context.Context, req *proto.RemoveMarginRuleRequest) (*proto.RemoveMarginRuleResponse, error)
+	ListMarginRules(ctx context.Context, req *proto.ListMarginRulesRequest) (*proto.ListMarginRulesResponse, error)
 }
 
 type productHandler struct {
 	proto.UnimplementedProductServiceServer
-	ProductService services.ProductService
+	ProductService     services.ProductService
+	BrandService       services.BrandService
+	InteractionService services.InteractionService
+	RecallService      services.RecallService
+	PriceListService   services.PriceListService
+	PromotionService   services.PromotionService
+	TaxService         services.TaxService
 }
 
-func NewProductHandler(productRepo repositories.ProductRepository, inventorylogRepo repositories.InventoryLogRepository) *productHandler {
+func NewProductHandler(productRepo repositories.ProductRepository, inventorylogRepo repositories.InventoryLogRepository, productVariantRepo repositories.ProductVariantRepository, productIdentifierRepo repositories.ProductIdentifierRepository, brandRepo repositories.BrandRepository, manufacturerRepo repositories.ManufacturerRepository, ingredientRepo repositories.IngredientRepository, interactionRepo repositories.InteractionRepository, productImageRepo repositories.ProductImageRepository, recallRepo repositories.RecallRepository, productVersionRepo repositories.ProductVersionRepository, productTranslationRepo repositories.ProductTranslationRepository, productRelationRepo repositories.ProductRelationRepository, bundleRepo repositories.BundleRepository, regionRuleRepo repositories.RegionRuleRepository, priceListRepo repositories.PriceListRepository, priceChangeRepo repositories.PriceChangeRepository, promotionRepo repositories.PromotionRepository, priceTierRepo repositories.PriceTierRepository, taxRateRepo repositories.TaxRateRepository, marginRuleRepo repositories.MarginRuleRepository) *productHandler {
+	priceListService := services.NewPriceListService(priceListRepo, productRepo, productVariantRepo)
+	promotionService := services.NewPromotionService(promotionRepo, productRepo, productVariantRepo, brandRepo, priceTierRepo, priceListService)
 	return &productHandler{
-		ProductService: services.NewProductService(productRepo, inventorylogRepo),
+		ProductService:     services.NewProductService(productRepo, inventorylogRepo, productVariantRepo, productIdentifierRepo, brandRepo, ingredientRepo, productImageRepo, productVersionRepo, productTranslationRepo, productRelationRepo, bundleRepo, regionRuleRepo, priceChangeRepo, priceTierRepo, marginRuleRepo, priceListRepo, priceListService, promotionService),
+		BrandService:       services.NewBrandService(brandRepo, manufacturerRepo),
+		InteractionService: services.NewInteractionService(interactionRepo, ingredientRepo, productRepo),
+		RecallService:      services.NewRecallService(recallRepo, productRepo, productVariantRepo, inventorylogRepo),
+		PriceListService:   priceListService,
+		PromotionService:   promotionService,
+		TaxService:         services.NewTaxService(taxRateRepo, productRepo, regionRuleRepo, promotionService),
 	}
 }
 
 func (h *productHandler) CreateProduct(ctx context.Context, req *proto.CreateProductRequest) (*proto.CreateProductResponse, error) {
-	product := &models.Product{
-		Name:                 req.Product.Name,
-		Description:          &req.Product.Description,
-		Price:                req.Product.Price,
-		Stock:                int(req.Product.Stock),
-		RequiresPrescription: req.Product.RequiresPrescription,
-		ImageURL:             &req.Product.ImageUrl,
+	product := toModelProduct(req.Product)
+
+	if req.Product.CostPrice != nil && !utils.IsAdmin(ctx) {
+		return &proto.CreateProductResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.AuthError),
+				Message: "Only admins can set cost prices",
+			},
+		}, nil
+	}
+
+	if req.Product.BrandId != "" {
+		brandId, err := uuid.Parse(req.Product.BrandId)
+		if err != nil {
+			return &proto.CreateProductResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(errors.ValidationError),
+					Message: "Invalid brand ID",
+					Details: utils.ConvertMapToKeyValuePairs(map[string]string{"brandId": fmt.Sprintf("Invalid UUID: %s", req.Product.BrandId)}),
+				},
+			}, nil
+		}
+		product.BrandID = &brandId
 	}
 
-	productID, err := h.ProductService.CreateProduct(product)
+	productID, err := h.ProductService.CreateProduct(product, utils.GetActor(ctx))
 	if err != nil {
 		if appErr, ok := errors.IsAppError(err); ok {
 			return &proto.CreateProductResponse{
@@ -65,20 +162,48 @@ func (h *productHandler) CreateProduct(ctx context.Context, req *proto.CreatePro
 		}, nil
 	}
 
+	var imageUrl string
+	if image := product.PrimaryImage(); image != nil {
+		imageUrl = image.URL
+	}
+
 	return &proto.CreateProductResponse{
 		Success:              true,
 		Id:                   productID,
 		Name:                 product.Name,
 		Description:          *product.Description,
 		Price:                product.Price,
+		PriceMoney:           toProtoMoney(product.UnitPrice()),
 		Stock:                int32(product.Stock),
 		RequiresPrescription: product.RequiresPrescription,
-		ImageUrl:             *product.ImageURL,
+		ImageUrl:             imageUrl,
 	}, nil
 }
 
 func (h *productHandler) GetProduct(ctx context.Context, req *proto.GetProductRequest) (*proto.GetProductResponse, error) {
-	product, err := h.ProductService.GetProduct(req.ProductId)
+	visibility, err := productVisibility(ctx, req.IncludeDeleted)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.GetProductResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.GetProductResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	locales := utils.LocaleFallbacks(utils.GetLocale(ctx, req.Locale))
+	product, err := h.ProductService.GetProduct(req.ProductId, visibility, locales, toModelPriceContext(req.PriceContext))
 	if err != nil {
 		if appErr, ok := errors.IsAppError(err); ok {
 			return &proto.GetProductResponse{
@@ -99,30 +224,92 @@ func (h *productHandler) GetProduct(ctx context.Context, req *proto.GetProductRe
 		}, nil
 	}
 
+	if req.IncludeRelations {
+		product.Relations, err = h.ProductService.ListRelations(product.ID.String(), req.RelationTypes, locales)
+		if err != nil {
+			if appErr, ok := errors.IsAppError(err); ok {
+				return &proto.GetProductResponse{
+					Success: false,
+					Error: &proto.Error{
+						Type:    string(appErr.Type),
+						Message: appErr.Message,
+						Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+					},
+				}, nil
+			}
+			return &proto.GetProductResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(errors.InternalError),
+					Message: "An unexpected error occurred",
+				},
+			}, nil
+		}
+	}
+
+	pbProduct := toProtoProduct(product)
+
+	// Only admins see what the product costs and earns
+	if utils.IsAdmin(ctx) && product.CostMinor != nil {
+		pbProduct.CostPrice = toProtoMoney(models.Money{Amount: *product.CostMinor, Currency: product.Currency})
+		margin := product.Margin()
+		pbProduct.Margin = &proto.Margin{
+			Amount:      toProtoMoney(margin.Amount),
+			BasisPoints: int32(margin.BasisPoints),
+		}
+	}
+
 	return &proto.GetProductResponse{
 		Success: true,
-		Product: &proto.Product{
-			Id:                   product.ID.String(),
-			Name:                 product.Name,
-			Description:          *product.Description,
-			Price:                product.Price,
-			Stock:                int32(product.Stock),
-			RequiresPrescription: product.RequiresPrescription,
-			ImageUrl:             *product.ImageURL,
-		},
+		Product: pbProduct,
 	}, nil
 }
 
 func (h *productHandler) ListProducts(ctx context.Context, req *proto.ListProductsRequest) (*proto.ListProductsResponse, error) {
-	var filter models.Filter
-	if req.Filter != nil {
-		filter = models.Filter{
-			Column:   req.Filter.Column,
-			Operator: req.Filter.Operator,
-			Value:    req.Filter.Value,
+	filter := toModelFilterExpression(req.Filter, req.FilterExpression)
+	visibility, err := productVisibility(ctx, req.IncludeDeleted)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.ListProductsResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
 		}
+		return &proto.ListProductsResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	var prescriptionClasses []string
+	for _, prescriptionClass := range req.PrescriptionClasses {
+		prescriptionClasses = append(prescriptionClasses, strings.ToLower(strings.TrimSpace(prescriptionClass)))
 	}
-	products, total, err := h.ProductService.ListProducts(req.Search, filter, req.SortBy, req.SortOrder, req.Page, req.Limit)
+
+	options := models.ProductListOptions{
+		BrandID:             req.BrandId,
+		ManufacturerID:      req.ManufacturerId,
+		DosageForm:          req.DosageForm,
+		Route:               req.Route,
+		StorageCondition:    req.StorageCondition,
+		RequiresColdChain:   req.RequiresColdChain,
+		Statuses:            req.Statuses,
+		PrescriptionClasses: prescriptionClasses,
+		Locales:             utils.LocaleFallbacks(utils.GetLocale(ctx, req.Locale)),
+		Region:              req.Region,
+		IncludeUnavailable:  req.IncludeUnavailableInRegion,
+		Visibility:          visibility,
+		Pricing:             toModelPriceContext(req.PriceContext),
+	}
+
+	products, total, err := h.ProductService.ListProducts(req.Search, filter, options, req.SortBy, req.SortOrder, req.Page, req.Limit)
 	if err != nil {
 		if appErr, ok := errors.IsAppError(err); ok {
 			return &proto.ListProductsResponse{
@@ -145,28 +332,95 @@ func (h *productHandler) ListProducts(ctx context.Context, req *proto.ListProduc
 
 	var pbProducts []*proto.Product
 	for _, product := range products {
-		pbProducts = append(pbProducts, &proto.Product{
-			Id:                   product.ID.String(),
-			Name:                 product.Name,
-			Description:          *product.Description,
-			Price:                product.Price,
-			Stock:                int32(product.Stock),
-			RequiresPrescription: product.RequiresPrescription,
-			ImageUrl:             *product.ImageURL,
-		})
+		pbProducts = append(pbProducts, toProtoProduct(&product))
+	}
+
+	var pbFacets []*proto.BrandFacet
+	if req.IncludeBrandFacets {
+		facets, err := h.ProductService.GetBrandFacets(req.Search, filter, options)
+		if err != nil {
+			if appErr, ok := errors.IsAppError(err); ok {
+				return &proto.ListProductsResponse{
+					Success: false,
+					Error: &proto.Error{
+						Type:    string(appErr.Type),
+						Message: appErr.Message,
+						Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+					},
+				}, nil
+			}
+			return &proto.ListProductsResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(errors.InternalError),
+					Message: "An unexpected error occurred",
+				},
+			}, nil
+		}
+
+		for _, facet := range facets {
+			pbFacets = append(pbFacets, &proto.BrandFacet{
+				BrandId:   facet.BrandID.String(),
+				BrandName: facet.BrandName,
+				Count:     int32(facet.Count),
+			})
+		}
 	}
 
 	return &proto.ListProductsResponse{
-		Success:  true,
-		Products: pbProducts,
-		Total:    total,
-		Page:     req.Page,
-		Limit:    req.Limit,
+		Success:     true,
+		Products:    pbProducts,
+		Total:       total,
+		Page:        req.Page,
+		Limit:       req.Limit,
+		BrandFacets: pbFacets,
 	}, nil
 }
 
 func (h *productHandler) UpdateProduct(ctx context.Context, req *proto.UpdateProductRequest) (*proto.UpdateProductResponse, error) {
-	err := h.ProductService.UpdateProduct(req.ProductId, req.Product.Name, req.Product.Description, req.Product.Price, req.Product.RequiresPrescription, req.Product.ImageUrl)
+	product := toModelProduct(req.Product)
+
+	// An empty tax category clears the one set on the product
+	if req.ClearTaxCategory {
+		product.TaxCategory = new(string)
+	}
+
+	if req.Product.CostPrice != nil && !utils.IsAdmin(ctx) {
+		return &proto.UpdateProductResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.AuthError),
+				Message: "Only admins can set cost prices",
+			},
+		}, nil
+	}
+
+	if req.OverrideMargin && !utils.IsAdmin(ctx) {
+		return &proto.UpdateProductResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.AuthError),
+				Message: "Only admins can override margin rules",
+			},
+		}, nil
+	}
+
+	if req.Product.BrandId != "" {
+		brandId, err := uuid.Parse(req.Product.BrandId)
+		if err != nil {
+			return &proto.UpdateProductResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(errors.ValidationError),
+					Message: "Invalid brand ID",
+					Details: utils.ConvertMapToKeyValuePairs(map[string]string{"brandId": fmt.Sprintf("Invalid UUID: %s", req.Product.BrandId)}),
+				},
+			}, nil
+		}
+		product.BrandID = &brandId
+	}
+
+	err := h.ProductService.UpdateProduct(req.ProductId, product, req.OverrideMargin, utils.GetActor(ctx))
 	if err != nil {
 		if appErr, ok := errors.IsAppError(err); ok {
 			return &proto.UpdateProductResponse{
@@ -194,7 +448,7 @@ func (h *productHandler) UpdateProduct(ctx context.Context, req *proto.UpdatePro
 }
 
 func (h *productHandler) DeleteProduct(ctx context.Context, req *proto.DeleteProductRequest) (*proto.DeleteProductResponse, error) {
-	err := h.ProductService.DeleteProduct(req.ProductId)
+	err := h.ProductService.DeleteProduct(req.ProductId, utils.GetActor(ctx))
 	if err != nil {
 		if appErr, ok := errors.IsAppError(err); ok {
 			return &proto.DeleteProductResponse{
@@ -221,6 +475,62 @@ func (h *productHandler) DeleteProduct(ctx context.Context, req *proto.DeletePro
 	}, nil
 }
 
+func (h *productHandler) RestoreProduct(ctx context.Context, req *proto.RestoreProductRequest) (*proto.RestoreProductResponse, error) {
+	err := h.ProductService.RestoreProduct(req.ProductId, utils.GetActor(ctx))
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.RestoreProductResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.RestoreProductResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	return &proto.RestoreProductResponse{
+		Success: true,
+		Message: "Product restored successfully",
+	}, nil
+}
+
+func (h *productHandler) UpdateProductStatus(ctx context.Context, req *proto.UpdateProductStatusRequest) (*proto.UpdateProductStatusResponse, error) {
+	err := h.ProductService.UpdateProductStatus(req.ProductId, strings.ToLower(strings.TrimSpace(req.Status)), utils.GetActor(ctx))
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.UpdateProductStatusResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.UpdateProductStatusResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	return &proto.UpdateProductStatusResponse{
+		Success: true,
+		Message: "Product status updated successfully",
+	}, nil
+}
+
 func (h *productHandler) UpdateStock(ctx context.Context, req *proto.UpdateStockRequest) (*proto.UpdateStockResponse, error) {
 	productId, err := uuid.Parse(req.ProductId)
 	if err != nil {
@@ -240,6 +550,40 @@ func (h *productHandler) UpdateStock(ctx context.Context, req *proto.UpdateStock
 		ChangeType:     req.Reason,
 	}
 
+	if req.VariantId != "" {
+		variantId, err := uuid.Parse(req.VariantId)
+		if err != nil {
+			return &proto.UpdateStockResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(errors.ValidationError),
+					Message: "Invalid variant ID",
+					Details: utils.ConvertMapToKeyValuePairs(map[string]string{"variantId": fmt.Sprintf("Invalid UUID: %s", req.VariantId)}),
+				},
+			}, nil
+		}
+		log.VariantID = &variantId
+	}
+
+	if req.OrderId != "" {
+		orderId, err := uuid.Parse(req.OrderId)
+		if err != nil {
+			return &proto.UpdateStockResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(errors.ValidationError),
+					Message: "Invalid order ID",
+					Details: utils.ConvertMapToKeyValuePairs(map[string]string{"orderId": fmt.Sprintf("Invalid UUID: %s", req.OrderId)}),
+				},
+			}, nil
+		}
+		log.OrderID = &orderId
+	}
+
+	if req.Region != "" {
+		log.Region = &req.Region
+	}
+
 	err = h.ProductService.UpdateStock(log)
 	if err != nil {
 		if appErr, ok := errors.IsAppError(err); ok {
@@ -268,14 +612,7 @@ func (h *productHandler) UpdateStock(ctx context.Context, req *proto.UpdateStock
 }
 
 func (h *productHandler) GetInventoryLogs(ctx context.Context, req *proto.GetInventoryLogsRequest) (*proto.GetInventoryLogsResponse, error) {
-	var filter models.Filter
-	if req.Filter != nil {
-		filter = models.Filter{
-			Column:   req.Filter.Column,
-			Operator: req.Filter.Operator,
-			Value:    req.Filter.Value,
-		}
-	}
+	filter := toModelFilterExpression(req.Filter, req.FilterExpression)
 
 	logs, total, err := h.ProductService.GetInventoryLogs(req.ProductId, filter, req.SortBy, req.SortOrder, req.Page, req.Limit)
 	if err != nil {
@@ -300,13 +637,7 @@ func (h *productHandler) GetInventoryLogs(ctx context.Context, req *proto.GetInv
 
 	var pbLogs []*proto.InventoryLog
 	for _, log := range logs {
-		pbLogs = append(pbLogs, &proto.InventoryLog{
-			Id:             log.ID.String(),
-			ProductId:      log.ProductID.String(),
-			QuantityChange: int32(log.QuantityChange),
-			ChangeType:     log.ChangeType,
-			CreatedAt:      log.CreatedAt.String(),
-		})
+		pbLogs = append(pbLogs, toProtoInventoryLog(&log))
 	}
 
 	return &proto.GetInventoryLogsResponse{
@@ -317,3 +648,790 @@ func (h *productHandler) GetInventoryLogs(ctx context.Context, req *proto.GetInv
 		Limit:   req.Limit,
 	}, nil
 }
+
+func (h *productHandler) AddProductVariant(ctx context.Context, req *proto.AddProductVariantRequest) (*proto.AddProductVariantResponse, error) {
+	productId, err := uuid.Parse(req.ProductId)
+	if err != nil {
+		return &proto.AddProductVariantResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.ValidationError),
+				Message: "Invalid product ID",
+				Details: utils.ConvertMapToKeyValuePairs(map[string]string{"productId": fmt.Sprintf("Invalid UUID: %s", req.ProductId)}),
+			},
+		}, nil
+	}
+
+	variant := &models.ProductVariant{
+		ProductID: productId,
+		SKU:       req.Variant.Sku,
+		Name:      req.Variant.Name,
+		Price:     req.Variant.Price,
+		Stock:     int(req.Variant.Stock),
+	}
+
+	if req.Variant.PriceMoney != nil {
+		variant.PriceMinor = req.Variant.PriceMoney.Amount
+	}
+
+	if req.OverrideMargin && !utils.IsAdmin(ctx) {
+		return &proto.AddProductVariantResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.AuthError),
+				Message: "Only admins can override margin rules",
+			},
+		}, nil
+	}
+
+	variantID, err := h.ProductService.AddVariant(variant, req.OverrideMargin, utils.GetActor(ctx))
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.AddProductVariantResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.AddProductVariantResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	return &proto.AddProductVariantResponse{
+		Success: true,
+		Id:      variantID,
+	}, nil
+}
+
+func (h *productHandler) UpdateProductVariant(ctx context.Context, req *proto.UpdateProductVariantRequest) (*proto.UpdateProductVariantResponse, error) {
+	update := &models.ProductVariant{
+		SKU:   req.Variant.Sku,
+		Name:  req.Variant.Name,
+		Price: req.Variant.Price,
+	}
+
+	if req.Variant.PriceMoney != nil {
+		update.PriceMinor = req.Variant.PriceMoney.Amount
+	}
+
+	if req.OverrideMargin && !utils.IsAdmin(ctx) {
+		return &proto.UpdateProductVariantResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.AuthError),
+				Message: "Only admins can override margin rules",
+			},
+		}, nil
+	}
+
+	err := h.ProductService.UpdateVariant(req.VariantId, update, req.OverrideMargin, utils.GetActor(ctx))
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.UpdateProductVariantResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.UpdateProductVariantResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	return &proto.UpdateProductVariantResponse{
+		Success: true,
+		Message: "Variant updated successfully",
+	}, nil
+}
+
+func (h *productHandler) DeleteProductVariant(ctx context.Context, req *proto.DeleteProductVariantRequest) (*proto.DeleteProductVariantResponse, error) {
+	err := h.ProductService.DeleteVariant(req.VariantId)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.DeleteProductVariantResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.DeleteProductVariantResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	return &proto.DeleteProductVariantResponse{
+		Success: true,
+		Message: "Variant deleted successfully",
+	}, nil
+}
+
+func (h *productHandler) AddProductIdentifier(ctx context.Context, req *proto.AddProductIdentifierRequest) (*proto.AddProductIdentifierResponse, error) {
+	productId, err := uuid.Parse(req.ProductId)
+	if err != nil {
+		return &proto.AddProductIdentifierResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.ValidationError),
+				Message: "Invalid product ID",
+				Details: utils.ConvertMapToKeyValuePairs(map[string]string{"productId": fmt.Sprintf("Invalid UUID: %s", req.ProductId)}),
+			},
+		}, nil
+	}
+
+	identifier := &models.ProductIdentifier{
+		ProductID: productId,
+		Type:      req.Type,
+		Value:     req.Value,
+	}
+
+	if req.VariantId != "" {
+		variantId, err := uuid.Parse(req.VariantId)
+		if err != nil {
+			return &proto.AddProductIdentifierResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(errors.ValidationError),
+					Message: "Invalid variant ID",
+					Details: utils.ConvertMapToKeyValuePairs(map[string]string{"variantId": fmt.Sprintf("Invalid UUID: %s", req.VariantId)}),
+				},
+			}, nil
+		}
+		identifier.VariantID = &variantId
+	}
+
+	identifierID, err := h.ProductService.AddIdentifier(identifier)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.AddProductIdentifierResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.AddProductIdentifierResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	return &proto.AddProductIdentifierResponse{
+		Success: true,
+		Id:      identifierID,
+		Type:    identifier.Type,
+		Value:   identifier.Value,
+	}, nil
+}
+
+func (h *productHandler) RemoveProductIdentifier(ctx context.Context, req *proto.RemoveProductIdentifierRequest) (*proto.RemoveProductIdentifierResponse, error) {
+	err := h.ProductService.RemoveIdentifier(req.IdentifierId)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.RemoveProductIdentifierResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.RemoveProductIdentifierResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	return &proto.RemoveProductIdentifierResponse{
+		Success: true,
+		Message: "Identifier removed successfully",
+	}, nil
+}
+
+func (h *productHandler) GetProductByIdentifier(ctx context.Context, req *proto.GetProductByIdentifierRequest) (*proto.GetProductByIdentifierResponse, error) {
+	visibility := models.ProductVisibility{
+		IncludeDrafts: utils.IsAdmin(ctx),
+	}
+
+	product, identifier, err := h.ProductService.GetProductByIdentifier(req.Type, req.Value, visibility, utils.LocaleFallbacks(utils.GetLocale(ctx, req.Locale)), toModelPriceContext(req.PriceContext))
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.GetProductByIdentifierResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.GetProductByIdentifierResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	var variantId string
+	if identifier.VariantID != nil {
+		variantId = identifier.VariantID.String()
+	}
+
+	return &proto.GetProductByIdentifierResponse{
+		Success:   true,
+		Product:   toProtoProduct(product),
+		VariantId: variantId,
+	}, nil
+}
+
+func (h *productHandler) GetProductBySlug(ctx context.Context, req *proto.GetProductBySlugRequest) (*proto.GetProductBySlugResponse, error) {
+	visibility := models.ProductVisibility{
+		IncludeDrafts: utils.IsAdmin(ctx),
+	}
+
+	product, redirected, err := h.ProductService.GetProductBySlug(req.Slug, visibility, utils.LocaleFallbacks(utils.GetLocale(ctx, req.Locale)), toModelPriceContext(req.PriceContext))
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.GetProductBySlugResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.GetProductBySlugResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	return &proto.GetProductBySlugResponse{
+		Success:    true,
+		Product:    toProtoProduct(product),
+		Redirected: redirected,
+	}, nil
+}
+
+func (h *productHandler) SetProductIngredients(ctx context.Context, req *proto.SetProductIngredientsRequest) (*proto.SetProductIngredientsResponse, error) {
+	var ingredients []models.ProductIngredient
+	for _, ingredient := range req.Ingredients {
+		ingredients = append(ingredients, models.ProductIngredient{
+			Ingredient:   &models.ActiveIngredient{Name: ingredient.IngredientName},
+			Strength:     ingredient.Strength,
+			StrengthUnit: ingredient.StrengthUnit,
+		})
+	}
+
+	err := h.ProductService.SetIngredients(req.ProductId, ingredients)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.SetProductIngredientsResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.SetProductIngredientsResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	return &proto.SetProductIngredientsResponse{
+		Success: true,
+		Message: "Product ingredients updated successfully",
+	}, nil
+}
+
+func (h *productHandler) FindEquivalents(ctx context.Context, req *proto.FindEquivalentsRequest) (*proto.FindEquivalentsResponse, error) {
+	products, err := h.ProductService.FindEquivalents(req.ProductId, req.Limit)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.FindEquivalentsResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.FindEquivalentsResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	var pbProducts []*proto.Product
+	for _, product := range products {
+		pbProducts = append(pbProducts, toProtoProduct(&product))
+	}
+
+	return &proto.FindEquivalentsResponse{
+		Success:  true,
+		Products: pbProducts,
+	}, nil
+}
+
+func (h *productHandler) AddProductImage(ctx context.Context, req *proto.AddProductImageRequest) (*proto.AddProductImageResponse, error) {
+	productId, err := uuid.Parse(req.ProductId)
+	if err != nil {
+		return &proto.AddProductImageResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.ValidationError),
+				Message: "Invalid product ID",
+				Details: utils.ConvertMapToKeyValuePairs(map[string]string{"productId": fmt.Sprintf("Invalid UUID: %s", req.ProductId)}),
+			},
+		}, nil
+	}
+
+	image := &models.ProductImage{
+		ProductID: productId,
+		URL:       req.Url,
+		IsPrimary: req.IsPrimary,
+	}
+
+	if req.AltText != "" {
+		image.AltText = &req.AltText
+	}
+
+	imageID, err := h.ProductService.AddImage(image)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.AddProductImageResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.AddProductImageResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	return &proto.AddProductImageResponse{
+		Success: true,
+		Id:      imageID,
+	}, nil
+}
+
+func (h *productHandler) ReorderProductImages(ctx context.Context, req *proto.ReorderProductImagesRequest) (*proto.ReorderProductImagesResponse, error) {
+	err := h.ProductService.ReorderImages(req.ProductId, req.ImageIds, req.PrimaryImageId)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.ReorderProductImagesResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.ReorderProductImagesResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	return &proto.ReorderProductImagesResponse{
+		Success: true,
+		Message: "Product images reordered successfully",
+	}, nil
+}
+
+func (h *productHandler) RemoveProductImage(ctx context.Context, req *proto.RemoveProductImageRequest) (*proto.RemoveProductImageResponse, error) {
+	err := h.ProductService.RemoveImage(req.ImageId)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.RemoveProductImageResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.RemoveProductImageResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	return &proto.RemoveProductImageResponse{
+		Success: true,
+		Message: "Product image removed successfully",
+	}, nil
+}
+
+// toModelProduct converts the editable fields of a proto product, associations are set by their own RPCs
+func toModelProduct(pbProduct *proto.Product) *models.Product {
+	product := &models.Product{
+		Name:                 pbProduct.Name,
+		Description:          &pbProduct.Description,
+		Price:                pbProduct.Price,
+		Stock:                int(pbProduct.Stock),
+		RequiresPrescription: pbProduct.RequiresPrescription,
+		DosageForm:           &pbProduct.DosageForm,
+		StrengthUnit:         &pbProduct.StrengthUnit,
+		Route:                &pbProduct.Route,
+		StorageCondition:     &pbProduct.StorageCondition,
+		RequiresColdChain:    pbProduct.RequiresColdChain,
+		Status:               strings.ToLower(strings.TrimSpace(pbProduct.Status)),
+		PrescriptionClass:    strings.ToLower(strings.TrimSpace(pbProduct.PrescriptionClass)),
+	}
+
+	if schedule := strings.ToUpper(strings.TrimSpace(pbProduct.ControlledSchedule)); schedule != "" {
+		product.ControlledSchedule = &schedule
+	}
+
+	if taxCategory := strings.ToLower(strings.TrimSpace(pbProduct.TaxCategory)); taxCategory != "" {
+		product.TaxCategory = &taxCategory
+	}
+
+	if pbProduct.PriceMoney != nil {
+		product.PriceMinor = pbProduct.PriceMoney.Amount
+		product.Currency = strings.ToUpper(strings.TrimSpace(pbProduct.PriceMoney.Currency))
+	}
+
+	if pbProduct.CostPrice != nil {
+		product.CostMinor = &pbProduct.CostPrice.Amount
+		product.CostCurrency = strings.ToUpper(strings.TrimSpace(pbProduct.CostPrice.Currency))
+	}
+
+	if pbProduct.Strength != 0 {
+		product.Strength = &pbProduct.Strength
+	}
+
+	if pbProduct.ImageUrl != "" {
+		product.Images = []models.ProductImage{{URL: pbProduct.ImageUrl, IsPrimary: true}}
+	}
+
+	return product
+}
+
+func toProtoMoney(money models.Money) *proto.Money {
+	return &proto.Money{
+		Amount:   money.Amount,
+		Currency: money.Currency,
+	}
+}
+
+// toProtoProduct converts a product, with any loaded associations, to its proto message
+func toProtoProduct(product *models.Product) *proto.Product {
+	var pbVariants []*proto.ProductVariant
+	for _, variant := range product.Variants {
+		pbVariants = append(pbVariants, &proto.ProductVariant{
+			Id:         variant.ID.String(),
+			ProductId:  variant.ProductID.String(),
+			Sku:        variant.SKU,
+			Name:       variant.Name,
+			Price:      variant.Price,
+			PriceMoney: toProtoMoney(models.Money{Amount: variant.PriceMinor, Currency: product.Currency}),
+			Stock:      int32(variant.Stock),
+		})
+	}
+
+	var pbIdentifiers []*proto.ProductIdentifier
+	for _, identifier := range product.Identifiers {
+		var variantId string
+		if identifier.VariantID != nil {
+			variantId = identifier.VariantID.String()
+		}
+
+		pbIdentifiers = append(pbIdentifiers, &proto.ProductIdentifier{
+			Id:        identifier.ID.String(),
+			ProductId: identifier.ProductID.String(),
+			VariantId: variantId,
+			Type:      identifier.Type,
+			Value:     identifier.Value,
+		})
+	}
+
+	var pbIngredients []*proto.ProductIngredient
+	for _, ingredient := range product.Ingredients {
+		pbIngredient := &proto.ProductIngredient{
+			IngredientId: ingredient.IngredientID.String(),
+			Strength:     ingredient.Strength,
+			StrengthUnit: ingredient.StrengthUnit,
+		}
+		if ingredient.Ingredient != nil {
+			pbIngredient.IngredientName = ingredient.Ingredient.Name
+		}
+		pbIngredients = append(pbIngredients, pbIngredient)
+	}
+
+	var pbImages []*proto.ProductImage
+	for _, image := range product.Images {
+		pbImage := &proto.ProductImage{
+			Id:        image.ID.String(),
+			Url:       image.URL,
+			SortOrder: int32(image.SortOrder),
+			IsPrimary: image.IsPrimary,
+		}
+		if image.AltText != nil {
+			pbImage.AltText = *image.AltText
+		}
+		pbImages = append(pbImages, pbImage)
+	}
+
+	pbProduct := &proto.Product{
+		Id:                   product.ID.String(),
+		Name:                 product.Name,
+		Description:          *product.Description,
+		Price:                product.Price,
+		PriceMoney:           toProtoMoney(product.UnitPrice()),
+		Stock:                int32(product.Stock),
+		RequiresPrescription: product.RequiresPrescription,
+		Variants:             pbVariants,
+		Identifiers:          pbIdentifiers,
+		Ingredients:          pbIngredients,
+		RequiresColdChain:    product.RequiresColdChain,
+		Images:               pbImages,
+		Status:               product.EffectiveStatus(),
+		Locale:               product.Locale,
+		IsBundle:             product.IsBundle,
+		PrescriptionClass:    product.PrescriptionClass,
+		TaxCategory:          product.EffectiveTaxCategory(),
+	}
+
+	if image := product.PrimaryImage(); image != nil {
+		pbProduct.ImageUrl = image.URL
+	}
+
+	for _, recall := range product.Recalls {
+		pbProduct.Recalls = append(pbProduct.Recalls, toProtoRecall(&recall))
+	}
+
+	if product.Slug != nil {
+		pbProduct.Slug = *product.Slug
+	}
+
+	if product.ControlledSchedule != nil {
+		pbProduct.ControlledSchedule = *product.ControlledSchedule
+	}
+
+	for _, component := range product.Components {
+		pbComponent := &proto.BundleComponent{
+			ComponentId: component.ComponentID.String(),
+			Quantity:    int32(component.Quantity),
+		}
+		if component.VariantID != nil {
+			pbComponent.VariantId = component.VariantID.String()
+		}
+		if component.Component != nil {
+			pbComponent.ComponentName = component.Component.Name
+		}
+		pbProduct.Components = append(pbProduct.Components, pbComponent)
+	}
+
+	for _, relation := range product.Relations {
+		pbProduct.Relations = append(pbProduct.Relations, toProtoProductRelation(&relation))
+	}
+
+	if product.Replacement != nil {
+		pbProduct.Replacement = toProtoProduct(product.Replacement)
+	}
+
+	if product.RegionAvailability != nil {
+		pbProduct.RegionAvailability = toProtoRegionAvailability(product.RegionAvailability)
+	}
+
+	for _, tier := range product.PriceTiers {
+		pbProduct.PriceTiers = append(pbProduct.PriceTiers, toProtoPriceTier(&tier, product.Currency))
+	}
+
+	if product.AppliedPrice != nil {
+		pbProduct.AppliedPrice = toProtoAppliedPrice(product.AppliedPrice)
+	}
+
+	if product.SalePrice != nil {
+		pbProduct.SalePrice = toProtoMoney(product.SalePrice.Price)
+		pbProduct.SalePromotionId = product.SalePrice.PromotionID.String()
+	}
+
+	if product.DeletedAt.Valid {
+		pbProduct.DeletedAt = product.DeletedAt.Time.String()
+	}
+
+	if product.DosageForm != nil {
+		pbProduct.DosageForm = *product.DosageForm
+	}
+
+	if product.Strength != nil && product.StrengthUnit != nil {
+		pbProduct.Strength = *product.Strength
+		pbProduct.StrengthUnit = *product.StrengthUnit
+	}
+
+	if product.Route != nil {
+		pbProduct.Route = *product.Route
+	}
+
+	if product.StorageCondition != nil {
+		pbProduct.StorageCondition = *product.StorageCondition
+	}
+
+	if product.BrandID != nil {
+		pbProduct.BrandId = product.BrandID.String()
+	}
+
+	if product.Brand != nil {
+		pbProduct.BrandName = product.Brand.Name
+		pbProduct.ManufacturerId = product.Brand.ManufacturerID.String()
+		if product.Brand.Manufacturer != nil {
+			pbProduct.ManufacturerName = product.Brand.Manufacturer.Name
+		}
+	}
+
+	return pbProduct
+}
+
+func toProtoInventoryLog(log *models.InventoryLog) *proto.InventoryLog {
+	pbLog := &proto.InventoryLog{
+		Id:             log.ID.String(),
+		ProductId:      log.ProductID.String(),
+		QuantityChange: int32(log.QuantityChange),
+		ChangeType:     log.ChangeType,
+		CreatedAt:      log.CreatedAt.String(),
+	}
+
+	if log.VariantID != nil {
+		pbLog.VariantId = log.VariantID.String()
+	}
+
+	if log.OrderID != nil {
+		pbLog.OrderId = log.OrderID.String()
+	}
+
+	return pbLog
+}
+
+// toModelFilterExpression combines the single filter of a request and its filter expression with AND
+func toModelFilterExpression(pbFilter *proto.Filter, pbExpression *proto.FilterExpression) models.FilterExpression {
+	expression := models.FilterExpression{Operator: models.FilterAnd}
+	if pbExpression != nil {
+		expression = toModelFilterGroup(pbExpression)
+	}
+
+	if pbFilter == nil || (pbFilter.Column == "" && pbFilter.Operator == "" && pbFilter.Value == "") {
+		return expression
+	}
+
+	// The single filter of older clients joins an AND expression at its root, so the expression keeps
+	// all of its nesting levels, and only wraps an OR expression
+	switch expression.Operator {
+	case "", models.FilterAnd:
+		expression.Conditions = append([]models.Filter{toModelFilter(pbFilter)}, expression.Conditions...)
+		return expression
+	}
+
+	return models.FilterExpression{
+		Operator:   models.FilterAnd,
+		Conditions: []models.Filter{toModelFilter(pbFilter)},
+		Groups:     []models.FilterExpression{expression},
+	}
+}
+
+func toModelFilterGroup(pbExpression *proto.FilterExpression) models.FilterExpression {
+	group := models.FilterExpression{
+		Operator: strings.ToLower(strings.TrimSpace(pbExpression.Operator)),
+	}
+
+	for _, pbFilter := range pbExpression.Conditions {
+		if pbFilter != nil {
+			group.Conditions = append(group.Conditions, toModelFilter(pbFilter))
+		}
+	}
+
+	for _, pbGroup := range pbExpression.Groups {
+		if pbGroup != nil {
+			group.Groups = append(group.Groups, toModelFilterGroup(pbGroup))
+		}
+	}
+
+	return group
+}
+
+func toModelFilter(pbFilter *proto.Filter) models.Filter {
+	return models.Filter{
+		Column:   pbFilter.Column,
+		Operator: pbFilter.Operator,
+		Value:    pbFilter.Value,
+	}
+}
+
+// productVisibility returns which products the caller may see, only admins can see drafts, deleted products
+// and cost prices
+func productVisibility(ctx context.Context, includeDeleted bool) (models.ProductVisibility, error) {
+	isAdmin := utils.IsAdmin(ctx)
+	if includeDeleted && !isAdmin {
+		return models.ProductVisibility{}, errors.NewAuthError("Only admins can view deleted products")
+	}
+
+	return models.ProductVisibility{
+		IncludeDrafts:  isAdmin,
+		IncludeDeleted: includeDeleted,
+		IncludeCost:    isAdmin,
+	}, nil
+}
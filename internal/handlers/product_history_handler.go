@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/PharmaKart/product-svc/internal/models"
+	"github.com/PharmaKart/product-svc/internal/proto"
+	"github.com/PharmaKart/product-svc/pkg/errors"
+	"github.com/PharmaKart/product-svc/pkg/utils"
+)
+
+func (h *productHandler) GetProductHistory(ctx context.Context, req *proto.GetProductHistoryRequest) (*proto.GetProductHistoryResponse, error) {
+	versions, total, err := h.ProductService.GetProductHistory(req.ProductId, req.Page, req.Limit)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.GetProductHistoryResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.GetProductHistoryResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	var pbVersions []*proto.ProductVersion
+	for _, version := range versions {
+		pbVersions = append(pbVersions, toProtoProductVersion(&version, utils.IsAdmin(ctx)))
+	}
+
+	return &proto.GetProductHistoryResponse{
+		Success:  true,
+		Versions: pbVersions,
+		Total:    total,
+		Page:     req.Page,
+		Limit:    req.Limit,
+	}, nil
+}
+
+func (h *productHandler) GetProductVersion(ctx context.Context, req *proto.GetProductVersionRequest) (*proto.GetProductVersionResponse, error) {
+	version, err := h.ProductService.GetProductVersion(req.ProductId, req.Version)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.GetProductVersionResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.GetProductVersionResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	snapshot, err := version.ProductSnapshot()
+	if err != nil {
+		return &proto.GetProductVersionResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	pbVersion := toProtoProductVersion(version, utils.IsAdmin(ctx))
+	pbVersion.Snapshot = toProtoProductSnapshot(version.ProductID.String(), &snapshot, utils.IsAdmin(ctx))
+
+	return &proto.GetProductVersionResponse{
+		Success: true,
+		Version: pbVersion,
+	}, nil
+}
+
+// toProtoProductVersion converts a version, changes of the cost price are only included for callers that may see it
+func toProtoProductVersion(version *models.ProductVersion, includeCost bool) *proto.ProductVersion {
+	var pbChanges []*proto.ProductFieldChange
+	for _, change := range version.Changes {
+		if change.Field == models.SnapshotFieldCost && !includeCost {
+			continue
+		}
+
+		pbChanges = append(pbChanges, &proto.ProductFieldChange{
+			Field:    change.Field,
+			OldValue: change.OldValue,
+			NewValue: change.NewValue,
+		})
+	}
+
+	return &proto.ProductVersion{
+		Version:   int32(version.Version),
+		Action:    version.Action,
+		Actor:     version.Actor,
+		CreatedAt: version.CreatedAt.String(),
+		Changes:   pbChanges,
+	}
+}
+
+func toProtoProductSnapshot(productID string, snapshot *models.ProductSnapshot, includeCost bool) *proto.Product {
+	pbProduct := &proto.Product{
+		Id:                   productID,
+		Name:                 snapshot.Name,
+		Price:                snapshot.Price,
+		RequiresPrescription: snapshot.RequiresPrescription,
+		PrescriptionClass:    snapshot.PrescriptionClass,
+		Status:               snapshot.Status,
+		RequiresColdChain:    snapshot.RequiresColdChain,
+	}
+
+	if snapshot.Description != nil {
+		pbProduct.Description = *snapshot.Description
+	}
+
+	// Versions recorded before prices were kept in minor units only have the decimal price
+	if snapshot.PriceMinor != 0 {
+		pbProduct.PriceMoney = toProtoMoney(models.Money{Amount: snapshot.PriceMinor, Currency: snapshot.Currency})
+	}
+
+	if snapshot.ControlledSchedule != nil {
+		pbProduct.ControlledSchedule = *snapshot.ControlledSchedule
+	}
+
+	if snapshot.TaxCategory != nil {
+		pbProduct.TaxCategory = *snapshot.TaxCategory
+	}
+
+	if includeCost && snapshot.CostMinor != nil {
+		pbProduct.CostPrice = toProtoMoney(models.Money{Amount: *snapshot.CostMinor, Currency: snapshot.Currency})
+	}
+
+	if snapshot.BrandID != nil {
+		pbProduct.BrandId = snapshot.BrandID.String()
+	}
+
+	if snapshot.DosageForm != nil {
+		pbProduct.DosageForm = *snapshot.DosageForm
+	}
+
+	if snapshot.Strength != nil && snapshot.StrengthUnit != nil {
+		pbProduct.Strength = *snapshot.Strength
+		pbProduct.StrengthUnit = *snapshot.StrengthUnit
+	}
+
+	if snapshot.Route != nil {
+		pbProduct.Route = *snapshot.Route
+	}
+
+	if snapshot.StorageCondition != nil {
+		pbProduct.StorageCondition = *snapshot.StorageCondition
+	}
+
+	return pbProduct
+}
@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/PharmaKart/product-svc/internal/models"
+	"github.com/PharmaKart/product-svc/internal/proto"
+	"github.com/PharmaKart/product-svc/pkg/errors"
+	"github.com/PharmaKart/product-svc/pkg/utils"
+	"github.com/google/uuid"
+)
+
+func (h *productHandler) AddProductRelation(ctx context.Context, req *proto.AddProductRelationRequest) (*proto.AddProductRelationResponse, error) {
+	productId, err := uuid.Parse(req.ProductId)
+	if err != nil {
+		return &proto.AddProductRelationResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.ValidationError),
+				Message: "Invalid product ID",
+				Details: utils.ConvertMapToKeyValuePairs(map[string]string{"productId": fmt.Sprintf("Invalid UUID: %s", req.ProductId)}),
+			},
+		}, nil
+	}
+
+	relatedProductId, err := uuid.Parse(req.RelatedProductId)
+	if err != nil {
+		return &proto.AddProductRelationResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.ValidationError),
+				Message: "Invalid related product ID",
+				Details: utils.ConvertMapToKeyValuePairs(map[string]string{"relatedProductId": fmt.Sprintf("Invalid UUID: %s", req.RelatedProductId)}),
+			},
+		}, nil
+	}
+
+	relation := &models.ProductRelation{
+		ProductID:        productId,
+		RelatedProductID: relatedProductId,
+		Type:             req.Type,
+		SortOrder:        int(req.SortOrder),
+	}
+
+	relationId, err := h.ProductService.AddRelation(relation)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.AddProductRelationResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.AddProductRelationResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	return &proto.AddProductRelationResponse{
+		Success: true,
+		Id:      relationId,
+	}, nil
+}
+
+func (h *productHandler) RemoveProductRelation(ctx context.Context, req *proto.RemoveProductRelationRequest) (*proto.RemoveProductRelationResponse, error) {
+	err := h.ProductService.RemoveRelation(req.RelationId)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.RemoveProductRelationResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.RemoveProductRelationResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	return &proto.RemoveProductRelationResponse{
+		Success: true,
+		Message: "Relation removed successfully",
+	}, nil
+}
+
+func (h *productHandler) ListProductRelations(ctx context.Context, req *proto.ListProductRelationsRequest) (*proto.ListProductRelationsResponse, error) {
+	var types []string
+	for _, relationType := range req.Types {
+		types = append(types, strings.ToLower(strings.TrimSpace(relationType)))
+	}
+
+	relations, err := h.ProductService.ListRelations(req.ProductId, types, utils.LocaleFallbacks(utils.GetLocale(ctx, req.Locale)))
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.ListProductRelationsResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.ListProductRelationsResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	var pbRelations []*proto.ProductRelation
+	for _, relation := range relations {
+		pbRelations = append(pbRelations, toProtoProductRelation(&relation))
+	}
+
+	return &proto.ListProductRelationsResponse{
+		Success:   true,
+		Relations: pbRelations,
+	}, nil
+}
+
+func toProtoProductRelation(relation *models.ProductRelation) *proto.ProductRelation {
+	pbRelation := &proto.ProductRelation{
+		Id:        relation.ID.String(),
+		Type:      relation.Type,
+		SortOrder: int32(relation.SortOrder),
+	}
+
+	if relation.RelatedProduct != nil {
+		pbRelation.RelatedProduct = toProtoProduct(relation.RelatedProduct)
+	}
+
+	return pbRelation
+}
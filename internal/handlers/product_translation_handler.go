@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/PharmaKart/product-svc/internal/models"
+	"github.com/PharmaKart/product-svc/internal/proto"
+	"github.com/PharmaKart/product-svc/pkg/errors"
+	"github.com/PharmaKart/product-svc/pkg/utils"
+	"github.com/google/uuid"
+)
+
+func (h *productHandler) SetProductTranslation(ctx context.Context, req *proto.SetProductTranslationRequest) (*proto.SetProductTranslationResponse, error) {
+	productId, err := uuid.Parse(req.ProductId)
+	if err != nil {
+		return &proto.SetProductTranslationResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.ValidationError),
+				Message: "Invalid product ID",
+				Details: utils.ConvertMapToKeyValuePairs(map[string]string{"productId": fmt.Sprintf("Invalid UUID: %s", req.ProductId)}),
+			},
+		}, nil
+	}
+
+	if req.Translation == nil {
+		return &proto.SetProductTranslationResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.ValidationError),
+				Message: "Translation is required",
+			},
+		}, nil
+	}
+
+	translation := &models.ProductTranslation{
+		ProductID: productId,
+		Locale:    req.Translation.Locale,
+		Name:      req.Translation.Name,
+	}
+
+	if req.Translation.Description != "" {
+		translation.Description = &req.Translation.Description
+	}
+
+	err = h.ProductService.SetTranslation(translation)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.SetProductTranslationResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.SetProductTranslationResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	return &proto.SetProductTranslationResponse{
+		Success: true,
+		Message: "Translation saved successfully",
+	}, nil
+}
+
+func (h *productHandler) RemoveProductTranslation(ctx context.Context, req *proto.RemoveProductTranslationRequest) (*proto.RemoveProductTranslationResponse, error) {
+	err := h.ProductService.RemoveTranslation(req.ProductId, req.Locale)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.RemoveProductTranslationResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.RemoveProductTranslationResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	return &proto.RemoveProductTranslationResponse{
+		Success: true,
+		Message: "Translation removed successfully",
+	}, nil
+}
+
+func (h *productHandler) ListProductTranslations(ctx context.Context, req *proto.ListProductTranslationsRequest) (*proto.ListProductTranslationsResponse, error) {
+	translations, err := h.ProductService.ListTranslations(req.ProductId)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.ListProductTranslationsResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.ListProductTranslationsResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	var pbTranslations []*proto.ProductTranslation
+	for _, translation := range translations {
+		pbTranslation := &proto.ProductTranslation{
+			Locale: translation.Locale,
+			Name:   translation.Name,
+		}
+		if translation.Description != nil {
+			pbTranslation.Description = *translation.Description
+		}
+		pbTranslations = append(pbTranslations, pbTranslation)
+	}
+
+	return &proto.ListProductTranslationsResponse{
+		Success:      true,
+		Translations: pbTranslations,
+	}, nil
+}
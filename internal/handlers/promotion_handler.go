@@ -0,0 +1,328 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/PharmaKart/product-svc/internal/models"
+	"github.com/PharmaKart/product-svc/internal/proto"
+	"github.com/PharmaKart/product-svc/pkg/errors"
+	"github.com/PharmaKart/product-svc/pkg/utils"
+	"github.com/google/uuid"
+)
+
+func (h *productHandler) CreatePromotion(ctx context.Context, req *proto.CreatePromotionRequest) (*proto.CreatePromotionResponse, error) {
+	if req.Promotion == nil {
+		return &proto.CreatePromotionResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.ValidationError),
+				Message: "Promotion is required",
+			},
+		}, nil
+	}
+
+	startsAt, err := time.Parse(time.RFC3339, req.Promotion.StartsAt)
+	if err != nil {
+		return &proto.CreatePromotionResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.ValidationError),
+				Message: "Invalid date",
+				Details: utils.ConvertMapToKeyValuePairs(map[string]string{"startsAt": fmt.Sprintf("Invalid RFC 3339 date: %s", req.Promotion.StartsAt)}),
+			},
+		}, nil
+	}
+
+	endsAt, err := time.Parse(time.RFC3339, req.Promotion.EndsAt)
+	if err != nil {
+		return &proto.CreatePromotionResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.ValidationError),
+				Message: "Invalid date",
+				Details: utils.ConvertMapToKeyValuePairs(map[string]string{"endsAt": fmt.Sprintf("Invalid RFC 3339 date: %s", req.Promotion.EndsAt)}),
+			},
+		}, nil
+	}
+
+	promotion := &models.Promotion{
+		Name:     req.Promotion.Name,
+		Type:     req.Promotion.Type,
+		StartsAt: startsAt,
+		EndsAt:   endsAt,
+	}
+
+	if req.Promotion.PercentOff != 0 {
+		percentOff := int(req.Promotion.PercentOff)
+		promotion.PercentOff = &percentOff
+	}
+
+	if req.Promotion.AmountOff != nil {
+		promotion.AmountOffMinor = &req.Promotion.AmountOff.Amount
+		promotion.Currency = &req.Promotion.AmountOff.Currency
+	}
+
+	if req.Promotion.BuyQuantity != 0 {
+		buyQuantity := int(req.Promotion.BuyQuantity)
+		promotion.BuyQuantity = &buyQuantity
+	}
+
+	if req.Promotion.GetQuantity != 0 {
+		getQuantity := int(req.Promotion.GetQuantity)
+		promotion.GetQuantity = &getQuantity
+	}
+
+	for _, pbTarget := range req.Promotion.Targets {
+		targetId, err := uuid.Parse(pbTarget.Id)
+		if err != nil {
+			return &proto.CreatePromotionResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(errors.ValidationError),
+					Message: "Invalid target ID",
+					Details: utils.ConvertMapToKeyValuePairs(map[string]string{"targetId": fmt.Sprintf("Invalid UUID: %s", pbTarget.Id)}),
+				},
+			}, nil
+		}
+
+		promotion.Targets = append(promotion.Targets, models.PromotionTarget{
+			TargetType: pbTarget.Type,
+			TargetID:   targetId,
+		})
+	}
+
+	promotionID, err := h.PromotionService.CreatePromotion(promotion)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.CreatePromotionResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.CreatePromotionResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	return &proto.CreatePromotionResponse{
+		Success: true,
+		Id:      promotionID,
+	}, nil
+}
+
+func (h *productHandler) ListPromotions(ctx context.Context, req *proto.ListPromotionsRequest) (*proto.ListPromotionsResponse, error) {
+	promotions, total, err := h.PromotionService.ListPromotions(req.ActiveOnly, req.Page, req.Limit)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.ListPromotionsResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.ListPromotionsResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	var pbPromotions []*proto.Promotion
+	for _, promotion := range promotions {
+		pbPromotions = append(pbPromotions, toProtoPromotion(&promotion))
+	}
+
+	return &proto.ListPromotionsResponse{
+		Success:    true,
+		Promotions: pbPromotions,
+		Total:      total,
+		Page:       req.Page,
+		Limit:      req.Limit,
+	}, nil
+}
+
+func (h *productHandler) DeletePromotion(ctx context.Context, req *proto.DeletePromotionRequest) (*proto.DeletePromotionResponse, error) {
+	err := h.PromotionService.DeletePromotion(req.PromotionId)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.DeletePromotionResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.DeletePromotionResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	return &proto.DeletePromotionResponse{
+		Success: true,
+		Message: "Promotion deleted successfully",
+	}, nil
+}
+
+func (h *productHandler) PriceQuote(ctx context.Context, req *proto.PriceQuoteRequest) (*proto.PriceQuoteResponse, error) {
+	var items []models.QuoteItem
+	for _, pbItem := range req.Items {
+		productId, err := uuid.Parse(pbItem.ProductId)
+		if err != nil {
+			return &proto.PriceQuoteResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(errors.ValidationError),
+					Message: "Invalid product ID",
+					Details: utils.ConvertMapToKeyValuePairs(map[string]string{"productId": fmt.Sprintf("Invalid UUID: %s", pbItem.ProductId)}),
+				},
+			}, nil
+		}
+
+		item := models.QuoteItem{
+			ProductID: productId,
+			Quantity:  int(pbItem.Quantity),
+		}
+
+		if pbItem.VariantId != "" {
+			variantId, err := uuid.Parse(pbItem.VariantId)
+			if err != nil {
+				return &proto.PriceQuoteResponse{
+					Success: false,
+					Error: &proto.Error{
+						Type:    string(errors.ValidationError),
+						Message: "Invalid variant ID",
+						Details: utils.ConvertMapToKeyValuePairs(map[string]string{"variantId": fmt.Sprintf("Invalid UUID: %s", pbItem.VariantId)}),
+					},
+				}, nil
+			}
+			item.VariantID = &variantId
+		}
+
+		items = append(items, item)
+	}
+
+	quote, err := h.PromotionService.PriceQuote(items, toModelPriceContext(req.PriceContext))
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.PriceQuoteResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.PriceQuoteResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	var pbLines []*proto.QuoteLine
+	for _, line := range quote.Lines {
+		pbLines = append(pbLines, toProtoQuoteLine(&line))
+	}
+
+	return &proto.PriceQuoteResponse{
+		Success:    true,
+		Lines:      pbLines,
+		Subtotal:   toProtoMoney(quote.Subtotal),
+		Discount:   toProtoMoney(quote.Discount),
+		Total:      toProtoMoney(quote.Total),
+		Promotions: toProtoAppliedPromotions(quote.Promotions),
+	}, nil
+}
+
+func toProtoQuoteLine(line *models.QuoteLine) *proto.QuoteLine {
+	pbLine := &proto.QuoteLine{
+		ProductId:  line.Item.ProductID.String(),
+		Quantity:   int32(line.Item.Quantity),
+		UnitPrice:  toProtoMoney(line.UnitPrice),
+		Subtotal:   toProtoMoney(line.Subtotal),
+		Discount:   toProtoMoney(line.Discount),
+		Total:      toProtoMoney(line.Total),
+		Promotions: toProtoAppliedPromotions(line.Promotions),
+	}
+
+	if line.Item.VariantID != nil {
+		pbLine.VariantId = line.Item.VariantID.String()
+	}
+
+	if line.PriceTier != nil {
+		pbLine.PriceTierMinQuantity = int32(line.PriceTier.MinQuantity)
+	}
+
+	return pbLine
+}
+
+func toProtoPromotion(promotion *models.Promotion) *proto.Promotion {
+	pbPromotion := &proto.Promotion{
+		Id:       promotion.ID.String(),
+		Name:     promotion.Name,
+		Type:     promotion.Type,
+		StartsAt: promotion.StartsAt.Format(time.RFC3339),
+		EndsAt:   promotion.EndsAt.Format(time.RFC3339),
+	}
+
+	if promotion.PercentOff != nil {
+		pbPromotion.PercentOff = int32(*promotion.PercentOff)
+	}
+
+	if promotion.AmountOffMinor != nil && promotion.Currency != nil {
+		pbPromotion.AmountOff = toProtoMoney(models.Money{Amount: *promotion.AmountOffMinor, Currency: *promotion.Currency})
+	}
+
+	if promotion.BuyQuantity != nil {
+		pbPromotion.BuyQuantity = int32(*promotion.BuyQuantity)
+	}
+
+	if promotion.GetQuantity != nil {
+		pbPromotion.GetQuantity = int32(*promotion.GetQuantity)
+	}
+
+	for _, target := range promotion.Targets {
+		pbPromotion.Targets = append(pbPromotion.Targets, &proto.PromotionTarget{
+			Type: target.TargetType,
+			Id:   target.TargetID.String(),
+		})
+	}
+
+	return pbPromotion
+}
+
+func toProtoAppliedPromotions(promotions []models.AppliedPromotion) []*proto.AppliedPromotion {
+	var pbPromotions []*proto.AppliedPromotion
+	for _, promotion := range promotions {
+		pbPromotions = append(pbPromotions, &proto.AppliedPromotion{
+			PromotionId: promotion.PromotionID.String(),
+			Name:        promotion.Name,
+			Discount:    toProtoMoney(promotion.Discount),
+		})
+	}
+	return pbPromotions
+}
@@ -0,0 +1,211 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/PharmaKart/product-svc/internal/models"
+	"github.com/PharmaKart/product-svc/internal/proto"
+	"github.com/PharmaKart/product-svc/pkg/errors"
+	"github.com/PharmaKart/product-svc/pkg/utils"
+	"github.com/google/uuid"
+)
+
+func (h *productHandler) CreateRecall(ctx context.Context, req *proto.CreateRecallRequest) (*proto.CreateRecallResponse, error) {
+	productId, err := uuid.Parse(req.ProductId)
+	if err != nil {
+		return &proto.CreateRecallResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.ValidationError),
+				Message: "Invalid product ID",
+				Details: utils.ConvertMapToKeyValuePairs(map[string]string{"productId": fmt.Sprintf("Invalid UUID: %s", req.ProductId)}),
+			},
+		}, nil
+	}
+
+	recall := &models.Recall{
+		ProductID:     productId,
+		Reason:        strings.TrimSpace(req.Reason),
+		SeverityClass: strings.ToLower(strings.TrimSpace(req.SeverityClass)),
+	}
+
+	if req.VariantId != "" {
+		variantId, err := uuid.Parse(req.VariantId)
+		if err != nil {
+			return &proto.CreateRecallResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(errors.ValidationError),
+					Message: "Invalid variant ID",
+					Details: utils.ConvertMapToKeyValuePairs(map[string]string{"variantId": fmt.Sprintf("Invalid UUID: %s", req.VariantId)}),
+				},
+			}, nil
+		}
+		recall.VariantID = &variantId
+	}
+
+	if req.EffectiveDate != "" {
+		effectiveDate, err := time.Parse(time.RFC3339, req.EffectiveDate)
+		if err != nil {
+			return &proto.CreateRecallResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(errors.ValidationError),
+					Message: "Invalid effective date",
+					Details: utils.ConvertMapToKeyValuePairs(map[string]string{"effectiveDate": fmt.Sprintf("Invalid RFC 3339 date: %s", req.EffectiveDate)}),
+				},
+			}, nil
+		}
+		recall.EffectiveDate = effectiveDate
+	}
+
+	recallId, err := h.RecallService.CreateRecall(recall)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.CreateRecallResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.CreateRecallResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	return &proto.CreateRecallResponse{
+		Success:  true,
+		RecallId: recallId,
+	}, nil
+}
+
+func (h *productHandler) ResolveRecall(ctx context.Context, req *proto.ResolveRecallRequest) (*proto.ResolveRecallResponse, error) {
+	err := h.RecallService.ResolveRecall(req.RecallId)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.ResolveRecallResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.ResolveRecallResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	return &proto.ResolveRecallResponse{
+		Success: true,
+		Message: "Recall resolved successfully",
+	}, nil
+}
+
+func (h *productHandler) ListRecalls(ctx context.Context, req *proto.ListRecallsRequest) (*proto.ListRecallsResponse, error) {
+	recalls, err := h.RecallService.ListRecalls(req.ProductId, req.ActiveOnly)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.ListRecallsResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.ListRecallsResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	var pbRecalls []*proto.Recall
+	for _, recall := range recalls {
+		pbRecalls = append(pbRecalls, toProtoRecall(&recall))
+	}
+
+	return &proto.ListRecallsResponse{
+		Success: true,
+		Recalls: pbRecalls,
+	}, nil
+}
+
+func (h *productHandler) GetRecallImpact(ctx context.Context, req *proto.GetRecallImpactRequest) (*proto.GetRecallImpactResponse, error) {
+	impact, err := h.RecallService.GetRecallImpact(req.RecallId, req.Page, req.Limit)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.GetRecallImpactResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.GetRecallImpactResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	var pbOrders []*proto.InventoryLog
+	for _, order := range impact.Orders {
+		pbOrders = append(pbOrders, toProtoInventoryLog(&order))
+	}
+
+	return &proto.GetRecallImpactResponse{
+		Success:     true,
+		Recall:      toProtoRecall(impact.Recall),
+		OnHandStock: int32(impact.OnHandStock),
+		Orders:      pbOrders,
+		TotalOrders: impact.TotalOrders,
+		Page:        req.Page,
+		Limit:       req.Limit,
+	}, nil
+}
+
+func toProtoRecall(recall *models.Recall) *proto.Recall {
+	pbRecall := &proto.Recall{
+		Id:            recall.ID.String(),
+		ProductId:     recall.ProductID.String(),
+		Reason:        recall.Reason,
+		SeverityClass: recall.SeverityClass,
+		EffectiveDate: recall.EffectiveDate.String(),
+		CreatedAt:     recall.CreatedAt.String(),
+		Active:        recall.IsActive(time.Now()),
+	}
+
+	if recall.VariantID != nil {
+		pbRecall.VariantId = recall.VariantID.String()
+	}
+
+	if recall.ResolvedAt != nil {
+		pbRecall.ResolvedAt = recall.ResolvedAt.String()
+	}
+
+	return pbRecall
+}
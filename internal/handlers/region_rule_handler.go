@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/PharmaKart/product-svc/internal/models"
+	"github.com/PharmaKart/product-svc/internal/proto"
+	"github.com/PharmaKart/product-svc/pkg/errors"
+	"github.com/PharmaKart/product-svc/pkg/utils"
+	"github.com/google/uuid"
+)
+
+func (h *productHandler) SetProductRegionRule(ctx context.Context, req *proto.SetProductRegionRuleRequest) (*proto.SetProductRegionRuleResponse, error) {
+	productId, err := uuid.Parse(req.ProductId)
+	if err != nil {
+		return &proto.SetProductRegionRuleResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.ValidationError),
+				Message: "Invalid product ID",
+				Details: utils.ConvertMapToKeyValuePairs(map[string]string{"productId": fmt.Sprintf("Invalid UUID: %s", req.ProductId)}),
+			},
+		}, nil
+	}
+
+	if req.Rule == nil {
+		return &proto.SetProductRegionRuleResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.ValidationError),
+				Message: "Rule is required",
+			},
+		}, nil
+	}
+
+	rule := &models.ProductRegionRule{
+		ProductID: productId,
+		Region:    req.Rule.Region,
+		Rule:      req.Rule.Rule,
+	}
+
+	if req.Rule.MaxQuantity != 0 {
+		maxQuantity := int(req.Rule.MaxQuantity)
+		rule.MaxQuantity = &maxQuantity
+	}
+
+	err = h.ProductService.SetRegionRule(rule)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.SetProductRegionRuleResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.SetProductRegionRuleResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	return &proto.SetProductRegionRuleResponse{
+		Success: true,
+		Message: "Region rule saved successfully",
+	}, nil
+}
+
+func (h *productHandler) RemoveProductRegionRule(ctx context.Context, req *proto.RemoveProductRegionRuleRequest) (*proto.RemoveProductRegionRuleResponse, error) {
+	err := h.ProductService.RemoveRegionRule(req.ProductId, req.Region)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.RemoveProductRegionRuleResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.RemoveProductRegionRuleResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	return &proto.RemoveProductRegionRuleResponse{
+		Success: true,
+		Message: "Region rule removed successfully",
+	}, nil
+}
+
+func (h *productHandler) ListProductRegionRules(ctx context.Context, req *proto.ListProductRegionRulesRequest) (*proto.ListProductRegionRulesResponse, error) {
+	rules, err := h.ProductService.ListRegionRules(req.ProductId)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.ListProductRegionRulesResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.ListProductRegionRulesResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	var pbRules []*proto.ProductRegionRule
+	for _, rule := range rules {
+		pbRules = append(pbRules, toProtoRegionRule(&rule))
+	}
+
+	return &proto.ListProductRegionRulesResponse{
+		Success: true,
+		Rules:   pbRules,
+	}, nil
+}
+
+func (h *productHandler) GetProductAvailabilityForRegion(ctx context.Context, req *proto.GetProductAvailabilityForRegionRequest) (*proto.GetProductAvailabilityForRegionResponse, error) {
+	product, availability, err := h.ProductService.GetProductAvailabilityForRegion(req.ProductId, req.Region)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.GetProductAvailabilityForRegionResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.GetProductAvailabilityForRegionResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	return &proto.GetProductAvailabilityForRegionResponse{
+		Success:      true,
+		ProductId:    product.ID.String(),
+		Availability: toProtoRegionAvailability(availability),
+	}, nil
+}
+
+func toProtoRegionRule(rule *models.ProductRegionRule) *proto.ProductRegionRule {
+	pbRule := &proto.ProductRegionRule{
+		Region: rule.Region,
+		Rule:   rule.Rule,
+	}
+
+	if rule.MaxQuantity != nil {
+		pbRule.MaxQuantity = int32(*rule.MaxQuantity)
+	}
+
+	return pbRule
+}
+
+func toProtoRegionAvailability(availability *models.RegionAvailability) *proto.RegionAvailability {
+	pbAvailability := &proto.RegionAvailability{
+		Region:               availability.Region,
+		Sellable:             availability.Sellable,
+		PrescriptionClass:    availability.PrescriptionClass,
+		RequiresPrescription: availability.RequiresPrescription,
+	}
+
+	if availability.MaxQuantity != nil {
+		pbAvailability.MaxQuantity = int32(*availability.MaxQuantity)
+	}
+
+	if availability.Rule != nil {
+		pbAvailability.Rule = toProtoRegionRule(availability.Rule)
+	}
+
+	return pbAvailability
+}
@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/PharmaKart/product-svc/internal/models"
+	"github.com/PharmaKart/product-svc/internal/proto"
+	"github.com/PharmaKart/product-svc/pkg/errors"
+	"github.com/PharmaKart/product-svc/pkg/utils"
+	"github.com/google/uuid"
+)
+
+func (h *productHandler) SetTaxRate(ctx context.Context, req *proto.SetTaxRateRequest) (*proto.SetTaxRateResponse, error) {
+	if req.TaxRate == nil {
+		return &proto.SetTaxRateResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.ValidationError),
+				Message: "Tax rate is required",
+			},
+		}, nil
+	}
+
+	rate := &models.TaxRate{
+		Region:           req.TaxRate.Region,
+		TaxCategory:      req.TaxRate.TaxCategory,
+		RateMilliPercent: int(req.TaxRate.RateMilliPercent),
+	}
+
+	err := h.TaxService.SetTaxRate(rate)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.SetTaxRateResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.SetTaxRateResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	return &proto.SetTaxRateResponse{
+		Success: true,
+		Message: "Tax rate saved successfully",
+	}, nil
+}
+
+func (h *productHandler) RemoveTaxRate(ctx context.Context, req *proto.RemoveTaxRateRequest) (*proto.RemoveTaxRateResponse, error) {
+	err := h.TaxService.RemoveTaxRate(req.Region, req.TaxCategory)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.RemoveTaxRateResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.RemoveTaxRateResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	return &proto.RemoveTaxRateResponse{
+		Success: true,
+		Message: "Tax rate removed successfully",
+	}, nil
+}
+
+func (h *productHandler) ListTaxRates(ctx context.Context, req *proto.ListTaxRatesRequest) (*proto.ListTaxRatesResponse, error) {
+	rates, err := h.TaxService.ListTaxRates(req.Region)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.ListTaxRatesResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.ListTaxRatesResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	var pbRates []*proto.TaxRate
+	for _, rate := range rates {
+		pbRates = append(pbRates, &proto.TaxRate{
+			Id:               rate.ID.String(),
+			Region:           rate.Region,
+			TaxCategory:      rate.TaxCategory,
+			RateMilliPercent: int32(rate.RateMilliPercent),
+			UpdatedAt:        rate.UpdatedAt.String(),
+		})
+	}
+
+	return &proto.ListTaxRatesResponse{
+		Success:  true,
+		TaxRates: pbRates,
+	}, nil
+}
+
+func (h *productHandler) CalculateTax(ctx context.Context, req *proto.CalculateTaxRequest) (*proto.CalculateTaxResponse, error) {
+	var items []models.QuoteItem
+	for _, pbItem := range req.Items {
+		productId, err := uuid.Parse(pbItem.ProductId)
+		if err != nil {
+			return &proto.CalculateTaxResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(errors.ValidationError),
+					Message: "Invalid product ID",
+					Details: utils.ConvertMapToKeyValuePairs(map[string]string{"productId": fmt.Sprintf("Invalid UUID: %s", pbItem.ProductId)}),
+				},
+			}, nil
+		}
+
+		item := models.QuoteItem{
+			ProductID: productId,
+			Quantity:  int(pbItem.Quantity),
+		}
+
+		if pbItem.VariantId != "" {
+			variantId, err := uuid.Parse(pbItem.VariantId)
+			if err != nil {
+				return &proto.CalculateTaxResponse{
+					Success: false,
+					Error: &proto.Error{
+						Type:    string(errors.ValidationError),
+						Message: "Invalid variant ID",
+						Details: utils.ConvertMapToKeyValuePairs(map[string]string{"variantId": fmt.Sprintf("Invalid UUID: %s", pbItem.VariantId)}),
+					},
+				}, nil
+			}
+			item.VariantID = &variantId
+		}
+
+		items = append(items, item)
+	}
+
+	calculation, err := h.TaxService.CalculateTax(items, req.Region, toModelPriceContext(req.PriceContext))
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return &proto.CalculateTaxResponse{
+				Success: false,
+				Error: &proto.Error{
+					Type:    string(appErr.Type),
+					Message: appErr.Message,
+					Details: utils.ConvertMapToKeyValuePairs(appErr.Details),
+				},
+			}, nil
+		}
+		return &proto.CalculateTaxResponse{
+			Success: false,
+			Error: &proto.Error{
+				Type:    string(errors.InternalError),
+				Message: "An unexpected error occurred",
+			},
+		}, nil
+	}
+
+	var pbLines []*proto.TaxLine
+	for _, line := range calculation.Lines {
+		pbLines = append(pbLines, &proto.TaxLine{
+			Line:             toProtoQuoteLine(&line.QuoteLine),
+			TaxCategory:      line.TaxCategory,
+			RateMilliPercent: int32(line.RateMilliPercent),
+			Tax:              toProtoMoney(line.Tax),
+		})
+	}
+
+	var pbCategories []*proto.TaxCategoryTotal
+	for _, category := range calculation.Categories {
+		pbCategories = append(pbCategories, &proto.TaxCategoryTotal{
+			TaxCategory:      category.TaxCategory,
+			RateMilliPercent: int32(category.RateMilliPercent),
+			Taxable:          toProtoMoney(category.Taxable),
+			Tax:              toProtoMoney(category.Tax),
+		})
+	}
+
+	return &proto.CalculateTaxResponse{
+		Success:    true,
+		Region:     calculation.Region,
+		Lines:      pbLines,
+		Categories: pbCategories,
+		Subtotal:   toProtoMoney(calculation.Subtotal),
+		Tax:        toProtoMoney(calculation.Tax),
+		Total:      toProtoMoney(calculation.Total),
+	}, nil
+}
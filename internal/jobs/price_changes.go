@@ -0,0 +1,37 @@
+package jobs
+
+import (
+	"time"
+
+	"github.com/PharmaKart/product-svc/internal/repositories"
+	"github.com/PharmaKart/product-svc/pkg/utils"
+)
+
+// StartScheduledPriceChanges applies scheduled price changes once their effective time has passed,
+// running once at startup and then on every interval
+func StartScheduledPriceChanges(priceChangeRepo repositories.PriceChangeRepository, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			applyDuePriceChanges(priceChangeRepo)
+			<-ticker.C
+		}
+	}()
+}
+
+func applyDuePriceChanges(priceChangeRepo repositories.PriceChangeRepository) {
+	applied, err := priceChangeRepo.ApplyDueChanges(time.Now())
+	if err != nil {
+		utils.Error("Failed to apply scheduled price changes", map[string]interface{}{
+			"error": err,
+		})
+	}
+
+	if applied > 0 {
+		utils.Info("Applied scheduled price changes", map[string]interface{}{
+			"count": applied,
+		})
+	}
+}
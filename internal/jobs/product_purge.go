@@ -0,0 +1,39 @@
+package jobs
+
+import (
+	"time"
+
+	"github.com/PharmaKart/product-svc/internal/repositories"
+	"github.com/PharmaKart/product-svc/pkg/utils"
+)
+
+// StartProductPurge permanently removes products that were soft deleted more than the retention period ago,
+// running once at startup and then on every interval
+func StartProductPurge(productRepo repositories.ProductRepository, retention time.Duration, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			purgeDeletedProducts(productRepo, retention)
+			<-ticker.C
+		}
+	}()
+}
+
+func purgeDeletedProducts(productRepo repositories.ProductRepository, retention time.Duration) {
+	purged, err := productRepo.PurgeDeletedProducts(time.Now().Add(-retention))
+	if err != nil {
+		utils.Error("Failed to purge deleted products", map[string]interface{}{
+			"error": err,
+		})
+		return
+	}
+
+	if purged > 0 {
+		utils.Info("Purged deleted products", map[string]interface{}{
+			"count":     purged,
+			"retention": retention.String(),
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package jobs
+
+import (
+	"github.com/PharmaKart/product-svc/internal/repositories"
+	"github.com/PharmaKart/product-svc/pkg/utils"
+)
+
+const slugBackfillBatchSize = 100
+
+// BackfillProductSlugs gives a slug to every product created before slugs were introduced
+func BackfillProductSlugs(productRepo repositories.ProductRepository) {
+	go func() {
+		var backfilled int
+		for {
+			ids, err := productRepo.ListProductIDsWithoutSlug(slugBackfillBatchSize)
+			if err != nil {
+				utils.Error("Failed to list products without slugs", map[string]interface{}{
+					"error": err,
+				})
+				return
+			}
+
+			if len(ids) == 0 {
+				break
+			}
+
+			for _, id := range ids {
+				product, err := productRepo.GetProductIncludingDeleted(id.String())
+				if err == nil {
+					_, err = productRepo.SetSlug(product.ID, product.Name)
+				}
+				if err != nil {
+					utils.Error("Failed to backfill product slug", map[string]interface{}{
+						"productId": id.String(),
+						"error":     err,
+					})
+					return
+				}
+				backfilled++
+			}
+		}
+
+		if backfilled > 0 {
+			utils.Info("Backfilled product slugs", map[string]interface{}{
+				"count": backfilled,
+			})
+		}
+	}()
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Brand struct {
+	ID             uuid.UUID     `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	ManufacturerID uuid.UUID     `gorm:"type:uuid;not null;index"`
+	Manufacturer   *Manufacturer `gorm:"foreignKey:ManufacturerID"`
+	Name           string        `gorm:"not null;uniqueIndex"`
+	CreatedAt      time.Time     `gorm:"type:timestamptz;default:now()"`
+	UpdatedAt      time.Time     `gorm:"type:timestamptz;default:now()"`
+}
+
+func (b *Brand) BeforeCreate(tx *gorm.DB) (err error) {
+	b.ID = uuid.New()
+	return
+}
+
+// BrandFacet is the number of products of a brand matching a product listing
+type BrandFacet struct {
+	BrandID   uuid.UUID
+	BrandName string
+	Count     int64
+}
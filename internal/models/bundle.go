@@ -0,0 +1,62 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BundleComponent is a product, or one of its variants, contained in a bundle in the given quantity.
+// Bundles have no stock of their own, it is derived from the stock of their components.
+type BundleComponent struct {
+	ID          uuid.UUID       `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	BundleID    uuid.UUID       `gorm:"type:uuid;not null;uniqueIndex:idx_bundle_component"`
+	ComponentID uuid.UUID       `gorm:"type:uuid;not null;uniqueIndex:idx_bundle_component;index"`
+	Component   *Product        `gorm:"foreignKey:ComponentID"`
+	VariantID   *uuid.UUID      `gorm:"type:uuid;uniqueIndex:idx_bundle_component"`
+	Variant     *ProductVariant `gorm:"foreignKey:VariantID"`
+	Quantity    int             `gorm:"not null;check:quantity > 0"`
+}
+
+func (bc *BundleComponent) BeforeCreate(tx *gorm.DB) (err error) {
+	bc.ID = uuid.New()
+	return
+}
+
+// AvailableStock returns how many of the component can be sold, which is none unless it is active and not recalled.
+// The component and variant must be loaded.
+func (bc *BundleComponent) AvailableStock() int {
+	if bc.Component == nil || bc.Component.EffectiveStatus() != ProductStatusActive {
+		return 0
+	}
+
+	if bc.VariantID == nil {
+		return bc.Component.Stock
+	}
+
+	for _, recall := range bc.Component.Recalls {
+		if recall.VariantID != nil && *recall.VariantID == *bc.VariantID {
+			return 0
+		}
+	}
+
+	if bc.Variant == nil {
+		return 0
+	}
+	return bc.Variant.Stock
+}
+
+// BundleStock returns how many bundles can be assembled from the stock of the components
+func BundleStock(components []BundleComponent) int {
+	if len(components) == 0 {
+		return 0
+	}
+
+	stock := -1
+	for i := range components {
+		available := components[i].AvailableStock() / components[i].Quantity
+		if stock == -1 || available < stock {
+			stock = available
+		}
+	}
+	return max(stock, 0)
+}
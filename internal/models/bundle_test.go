@@ -0,0 +1,43 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestBundleStock(t *testing.T) {
+	variantID := uuid.New()
+	active := func(stock int) *Product {
+		return &Product{Status: ProductStatusActive, Stock: stock}
+	}
+	recalled := &Product{Status: ProductStatusActive, Stock: 100, Recalls: []Recall{{EffectiveDate: time.Now().Add(-time.Hour)}}}
+	variantRecalled := &Product{Status: ProductStatusActive, Recalls: []Recall{{VariantID: &variantID, EffectiveDate: time.Now().Add(-time.Hour)}}}
+
+	tests := []struct {
+		name       string
+		components []BundleComponent
+		want       int
+	}{
+		{"no components", nil, 0},
+		{"single component", []BundleComponent{{Component: active(10), Quantity: 1}}, 10},
+		{"quantity divides stock", []BundleComponent{{Component: active(10), Quantity: 3}}, 3},
+		{"scarcest component limits", []BundleComponent{{Component: active(10), Quantity: 1}, {Component: active(9), Quantity: 2}}, 4},
+		{"variant stock", []BundleComponent{{Component: active(0), VariantID: &variantID, Variant: &ProductVariant{Stock: 6}, Quantity: 2}}, 3},
+		{"unloaded variant", []BundleComponent{{Component: active(10), VariantID: &variantID, Quantity: 1}}, 0},
+		{"discontinued component", []BundleComponent{{Component: &Product{Status: ProductStatusDiscontinued, Stock: 10}, Quantity: 1}}, 0},
+		{"recalled component", []BundleComponent{{Component: active(10), Quantity: 1}, {Component: recalled, Quantity: 1}}, 0},
+		{"recalled variant", []BundleComponent{{Component: variantRecalled, VariantID: &variantID, Variant: &ProductVariant{Stock: 6}, Quantity: 1}}, 0},
+		{"unloaded component", []BundleComponent{{Quantity: 1}}, 0},
+		{"negative stock", []BundleComponent{{Component: active(-5), Quantity: 1}}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := BundleStock(tt.components); got != tt.want {
+				t.Errorf("BundleStock() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
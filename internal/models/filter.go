@@ -6,3 +6,54 @@ type Filter struct {
 	Operator string `json:"operator"`
 	Value    string `json:"value"`
 }
+
+// Filter expression operators
+const (
+	FilterAnd = "and"
+	FilterOr  = "or"
+)
+
+// FilterExpression combines conditions and nested groups of conditions with AND or OR
+type FilterExpression struct {
+	Operator   string             `json:"operator"` // Defaults to and
+	Conditions []Filter           `json:"conditions"`
+	Groups     []FilterExpression `json:"groups"`
+}
+
+// IsEmpty reports whether the expression has no conditions in any of its groups
+func (e FilterExpression) IsEmpty() bool {
+	if len(e.Conditions) > 0 {
+		return false
+	}
+
+	for _, group := range e.Groups {
+		if !group.IsEmpty() {
+			return false
+		}
+	}
+	return true
+}
+
+// ProductListOptions defines the product specific filters of a product listing
+type ProductListOptions struct {
+	BrandID             string            `json:"brand_id"`
+	ManufacturerID      string            `json:"manufacturer_id"`
+	DosageForm          string            `json:"dosage_form"`
+	Route               string            `json:"route"`
+	StorageCondition    string            `json:"storage_condition"`
+	RequiresColdChain   *bool             `json:"requires_cold_chain"`
+	Statuses            []string          `json:"statuses"`
+	Locales             []string          `json:"locales"`
+	PrescriptionClasses []string          `json:"prescription_classes"`
+	Region              string            `json:"region"`
+	IncludeUnavailable  bool              `json:"include_unavailable"` // Flags instead of hides products not sold in the region
+	Visibility          ProductVisibility `json:"-"`
+	Pricing             PriceContext      `json:"-"`
+}
+
+// ProductVisibility defines which hidden products and fields a caller may see
+type ProductVisibility struct {
+	IncludeDrafts  bool
+	IncludeDeleted bool
+	IncludeCost    bool // Lets the caller filter and sort by the cost price
+}
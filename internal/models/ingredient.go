@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ActiveIngredient struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	Name      string    `gorm:"not null;uniqueIndex"`
+	CreatedAt time.Time `gorm:"type:timestamptz;default:now()"`
+}
+
+func (ai *ActiveIngredient) BeforeCreate(tx *gorm.DB) (err error) {
+	ai.ID = uuid.New()
+	return
+}
+
+// ProductIngredient is the amount of an active ingredient in one unit of a product's dosage form
+type ProductIngredient struct {
+	ID           uuid.UUID         `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	ProductID    uuid.UUID         `gorm:"type:uuid;not null;uniqueIndex:idx_product_ingredient"`
+	IngredientID uuid.UUID         `gorm:"type:uuid;not null;uniqueIndex:idx_product_ingredient;index"`
+	Ingredient   *ActiveIngredient `gorm:"foreignKey:IngredientID"`
+	Strength     float64           `gorm:"type:numeric(12,4);not null;check:strength > 0"`
+	StrengthUnit string            `gorm:"type:varchar(10);not null"`
+}
+
+func (pi *ProductIngredient) BeforeCreate(tx *gorm.DB) (err error) {
+	pi.ID = uuid.New()
+	return
+}
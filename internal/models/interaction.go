@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// InteractionSeverities ranks the interaction severity levels from least to most severe
+var InteractionSeverities = map[string]int{
+	"minor":           1,
+	"moderate":        2,
+	"major":           3,
+	"contraindicated": 4,
+}
+
+// IngredientInteraction is a known interaction between two active ingredients. The pair is stored once, with
+// IngredientAID sorting before IngredientBID; a pair of the same ingredient flags duplicate therapy.
+type IngredientInteraction struct {
+	ID            uuid.UUID         `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	IngredientAID uuid.UUID         `gorm:"column:ingredient_a_id;type:uuid;not null;uniqueIndex:idx_interaction_pair"`
+	IngredientA   *ActiveIngredient `gorm:"foreignKey:IngredientAID"`
+	IngredientBID uuid.UUID         `gorm:"column:ingredient_b_id;type:uuid;not null;uniqueIndex:idx_interaction_pair;index"`
+	IngredientB   *ActiveIngredient `gorm:"foreignKey:IngredientBID"`
+	Severity      string            `gorm:"type:varchar(20);not null;check:severity IN ('minor', 'moderate', 'major', 'contraindicated')"`
+	Description   string            `gorm:"not null"`
+	CreatedAt     time.Time         `gorm:"type:timestamptz;default:now()"`
+	UpdatedAt     time.Time         `gorm:"type:timestamptz;default:now()"`
+}
+
+func (ii *IngredientInteraction) BeforeCreate(tx *gorm.DB) (err error) {
+	ii.ID = uuid.New()
+	return
+}
+
+// ProductInteraction is an ingredient interaction found between two products of a basket
+type ProductInteraction struct {
+	ProductAID  uuid.UUID
+	ProductBID  uuid.UUID
+	IngredientA string
+	IngredientB string
+	Severity    string
+	Description string
+}
@@ -8,11 +8,14 @@ import (
 )
 
 type InventoryLog struct {
-	ID             uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
-	ProductID      uuid.UUID `gorm:"not null"`
-	ChangeType     string    `gorm:"type:varchar(50);not null;check:change_type IN ('order_placed', 'order_cancelled', 'stock_added')"`
-	QuantityChange int       `gorm:"not null"`
-	CreatedAt      time.Time `gorm:"type:timestamptz;default:now()"`
+	ID             uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	ProductID      uuid.UUID  `gorm:"not null"`
+	VariantID      *uuid.UUID `gorm:"type:uuid;index"`
+	OrderID        *uuid.UUID `gorm:"type:uuid;index"`
+	Region         *string    `gorm:"type:varchar(10)"`
+	ChangeType     string     `gorm:"type:varchar(50);not null;check:change_type IN ('order_placed', 'order_cancelled', 'stock_added')"`
+	QuantityChange int        `gorm:"not null"`
+	CreatedAt      time.Time  `gorm:"type:timestamptz;default:now()"`
 }
 
 func (il *InventoryLog) BeforeCreate(tx *gorm.DB) (err error) {
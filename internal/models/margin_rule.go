@@ -0,0 +1,90 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Margin rule targets, a product rule takes precedence over a brand rule and a brand rule over the rule for all products
+const (
+	MarginRuleTargetAll     = "all"
+	MarginRuleTargetBrand   = "brand"
+	MarginRuleTargetProduct = "product"
+)
+
+// MarginRuleTargetTypes are the allowed margin rule targets
+var MarginRuleTargetTypes = map[string]bool{
+	MarginRuleTargetAll: true, MarginRuleTargetBrand: true, MarginRuleTargetProduct: true,
+}
+
+// How a margin rule handles prices below its minimum margin
+const (
+	MarginEnforcementReject   = "reject"   // The price cannot be saved
+	MarginEnforcementOverride = "override" // The price is only saved with the override flag
+)
+
+// MarginEnforcements are the allowed margin rule enforcements
+var MarginEnforcements = map[string]bool{
+	MarginEnforcementReject: true, MarginEnforcementOverride: true,
+}
+
+// MarginRule sets the lowest margin a product may be priced at, in basis points of the price. A minimum of 0
+// only stops prices below cost.
+type MarginRule struct {
+	ID                   uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	TargetType           string     `gorm:"type:varchar(20);not null;uniqueIndex:idx_margin_rule_all,where:target_type = 'all';check:target_type IN ('all', 'brand', 'product')"`
+	TargetID             *uuid.UUID `gorm:"type:uuid;uniqueIndex:idx_margin_rule_target"` // Unset for the rule for all products
+	MinMarginBasisPoints int        `gorm:"not null;check:min_margin_basis_points BETWEEN 0 AND 9999"`
+	Enforcement          string     `gorm:"type:varchar(20);not null;check:enforcement IN ('reject', 'override')"`
+	CreatedAt            time.Time  `gorm:"type:timestamptz;default:now()"`
+	UpdatedAt            time.Time  `gorm:"type:timestamptz;default:now()"`
+}
+
+func (mr *MarginRule) BeforeCreate(tx *gorm.DB) (err error) {
+	mr.ID = uuid.New()
+	return
+}
+
+// Allows reports whether a price keeps at least the minimum margin over a cost
+func (mr *MarginRule) Allows(price Money, costMinor int64) bool {
+	return (price.Amount-costMinor)*10000 >= int64(mr.MinMarginBasisPoints)*price.Amount
+}
+
+// MarginRuleFor returns the most specific of the rules that apply to a product, or nil when none does
+func MarginRuleFor(rules []MarginRule, product *Product) *MarginRule {
+	var rule *MarginRule
+	rank := 0
+	for i := range rules {
+		var ruleRank int
+		switch {
+		case rules[i].TargetType == MarginRuleTargetProduct && rules[i].TargetID != nil && *rules[i].TargetID == product.ID:
+			ruleRank = 3
+		case rules[i].TargetType == MarginRuleTargetBrand && rules[i].TargetID != nil && product.BrandID != nil && *rules[i].TargetID == *product.BrandID:
+			ruleRank = 2
+		case rules[i].TargetType == MarginRuleTargetAll:
+			ruleRank = 1
+		}
+
+		if ruleRank > rank {
+			rule = &rules[i]
+			rank = ruleRank
+		}
+	}
+	return rule
+}
+
+// Margin is what a price earns over a cost, negative below cost
+type Margin struct {
+	Amount      Money
+	BasisPoints int // Share of the price, 2500 is 25%
+}
+
+func NewMargin(price Money, costMinor int64) Margin {
+	margin := Margin{Amount: Money{Amount: price.Amount - costMinor, Currency: price.Currency}}
+	if price.Amount > 0 {
+		margin.BasisPoints = int(margin.Amount.Amount * 10000 / price.Amount)
+	}
+	return margin
+}
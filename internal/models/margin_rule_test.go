@@ -0,0 +1,98 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestMarginRuleAllows(t *testing.T) {
+	tests := []struct {
+		minMarginBasisPoints int
+		price                int64
+		cost                 int64
+		want                 bool
+	}{
+		{2500, 1000, 750, true}, // Exactly 25%
+		{2500, 1000, 751, false},
+		{2500, 1000, 500, true},
+		{2500, 1000, 1200, false},
+		{0, 1000, 1000, true}, // At cost
+		{0, 1000, 1001, false},
+		{0, 1000, 0, true},
+		{9999, 10000, 1, true},
+		{9999, 10000, 2, false},
+		{1, 3, 2, true}, // 3333 basis points
+	}
+
+	for _, tt := range tests {
+		rule := &MarginRule{MinMarginBasisPoints: tt.minMarginBasisPoints}
+		if got := rule.Allows(Money{Amount: tt.price, Currency: "CAD"}, tt.cost); got != tt.want {
+			t.Errorf("Allows(%d, %d) at %d = %v, want %v", tt.price, tt.cost, tt.minMarginBasisPoints, got, tt.want)
+		}
+	}
+}
+
+func TestMarginRuleFor(t *testing.T) {
+	productID := uuid.New()
+	brandID := uuid.New()
+	otherID := uuid.New()
+
+	all := MarginRule{TargetType: MarginRuleTargetAll, MinMarginBasisPoints: 1000}
+	brand := MarginRule{TargetType: MarginRuleTargetBrand, TargetID: &brandID, MinMarginBasisPoints: 2000}
+	product := MarginRule{TargetType: MarginRuleTargetProduct, TargetID: &productID, MinMarginBasisPoints: 3000}
+	otherBrand := MarginRule{TargetType: MarginRuleTargetBrand, TargetID: &otherID, MinMarginBasisPoints: 4000}
+	otherProduct := MarginRule{TargetType: MarginRuleTargetProduct, TargetID: &otherID, MinMarginBasisPoints: 5000}
+
+	tests := []struct {
+		name    string
+		rules   []MarginRule
+		brandID *uuid.UUID
+		want    int // Minimum margin of the rule, -1 for none
+	}{
+		{"no rules", nil, &brandID, -1},
+		{"rule for all products", []MarginRule{all}, &brandID, 1000},
+		{"brand beats all", []MarginRule{all, brand}, &brandID, 2000},
+		{"product beats brand", []MarginRule{product, brand, all}, &brandID, 3000},
+		{"product beats all", []MarginRule{all, product}, nil, 3000},
+		{"brand rule needs product brand", []MarginRule{all, brand}, nil, 1000},
+		{"other brand ignored", []MarginRule{all, otherBrand}, &brandID, 1000},
+		{"other product ignored", []MarginRule{otherProduct, brand}, &brandID, 2000},
+		{"only rules of others", []MarginRule{otherBrand, otherProduct}, &brandID, -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := -1
+			if rule := MarginRuleFor(tt.rules, &Product{ID: productID, BrandID: tt.brandID}); rule != nil {
+				got = rule.MinMarginBasisPoints
+			}
+			if got != tt.want {
+				t.Errorf("MarginRuleFor() minimum = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewMargin(t *testing.T) {
+	tests := []struct {
+		price           int64
+		cost            int64
+		wantAmount      int64
+		wantBasisPoints int
+	}{
+		{1000, 750, 250, 2500},
+		{1000, 1000, 0, 0},
+		{1000, 1200, -200, -2000},
+		{3, 1, 2, 6666},
+		{1999, 0, 1999, 10000},
+		{0, 100, -100, 0},
+	}
+
+	for _, tt := range tests {
+		got := NewMargin(Money{Amount: tt.price, Currency: "CAD"}, tt.cost)
+		if got.Amount.Amount != tt.wantAmount || got.Amount.Currency != "CAD" || got.BasisPoints != tt.wantBasisPoints {
+			t.Errorf("NewMargin(%d, %d) = %v at %d, want %d CAD at %d", tt.price, tt.cost, got.Amount, got.BasisPoints, tt.wantAmount, tt.wantBasisPoints)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"math"
+	"strconv"
+)
+
+// DefaultCurrency is the currency of prices that do not name one
+const DefaultCurrency = "CAD"
+
+// CurrencyExponents are the supported ISO 4217 currencies with the number of digits of their minor unit
+var CurrencyExponents = map[string]int{
+	"CAD": 2, "USD": 2, "EUR": 2, "GBP": 2, "JPY": 0,
+}
+
+// Money is an exact amount in the minor unit of its currency, e.g. 1999 CAD is $19.99
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+// Decimal returns the amount in the major unit of the currency, for readers of the deprecated decimal prices
+func (m Money) Decimal() float64 {
+	return float64(m.Amount) / math.Pow10(CurrencyExponents[m.Currency])
+}
+
+// String formats the amount in the major unit of the currency, e.g. "19.99 CAD"
+func (m Money) String() string {
+	return strconv.FormatFloat(m.Decimal(), 'f', CurrencyExponents[m.Currency], 64) + " " + m.Currency
+}
+
+// MinorUnits converts a decimal amount to the minor unit of a currency, rounding to the nearest unit
+func MinorUnits(amount float64, currency string) int64 {
+	return int64(math.Round(amount * math.Pow10(CurrencyExponents[currency])))
+}
+
+// HasCurrencyPrecision checks that a decimal amount has no more decimals than the minor unit of its
+// currency, e.g. 19.99 fits CAD but 19.999 does not
+func HasCurrencyPrecision(amount float64, currency string) bool {
+	scaled := amount * math.Pow10(CurrencyExponents[currency])
+	return math.Abs(scaled-math.Round(scaled)) < 1e-6
+}
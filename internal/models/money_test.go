@@ -0,0 +1,75 @@
+package models
+
+import "testing"
+
+func TestMoneyDecimalAndString(t *testing.T) {
+	tests := []struct {
+		money       Money
+		wantDecimal float64
+		wantString  string
+	}{
+		{Money{Amount: 1999, Currency: "CAD"}, 19.99, "19.99 CAD"},
+		{Money{Amount: 5, Currency: "USD"}, 0.05, "0.05 USD"},
+		{Money{Amount: 100000, Currency: "EUR"}, 1000, "1000.00 EUR"},
+		{Money{Amount: 1500, Currency: "JPY"}, 1500, "1500 JPY"},
+		{Money{Amount: -250, Currency: "GBP"}, -2.5, "-2.50 GBP"},
+		{Money{Amount: 0, Currency: "CAD"}, 0, "0.00 CAD"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.money.Decimal(); got != tt.wantDecimal {
+			t.Errorf("%+v.Decimal() = %v, want %v", tt.money, got, tt.wantDecimal)
+		}
+		if got := tt.money.String(); got != tt.wantString {
+			t.Errorf("%+v.String() = %q, want %q", tt.money, got, tt.wantString)
+		}
+	}
+}
+
+// MinorUnits takes amounts that have the precision of their currency, some of which scale to inexact floats
+func TestMinorUnits(t *testing.T) {
+	tests := []struct {
+		amount   float64
+		currency string
+		want     int64
+	}{
+		{19.99, "CAD", 1999},
+		{0.29, "USD", 29},
+		{0.07, "EUR", 7},
+		{1.15, "EUR", 115},
+		{4.35, "CAD", 435},
+		{1234567.89, "GBP", 123456789},
+		{1500, "JPY", 1500},
+		{1500.4, "JPY", 1500},
+		{-2.5, "CAD", -250},
+	}
+
+	for _, tt := range tests {
+		if got := MinorUnits(tt.amount, tt.currency); got != tt.want {
+			t.Errorf("MinorUnits(%v, %q) = %d, want %d", tt.amount, tt.currency, got, tt.want)
+		}
+	}
+}
+
+func TestHasCurrencyPrecision(t *testing.T) {
+	tests := []struct {
+		amount   float64
+		currency string
+		want     bool
+	}{
+		{19.99, "CAD", true},
+		{0.1 + 0.2, "CAD", true},
+		{19.999, "CAD", false},
+		{19.9, "USD", true},
+		{1234567.89, "EUR", true},
+		{1500, "JPY", true},
+		{1500.5, "JPY", false},
+		{0.01, "JPY", false},
+	}
+
+	for _, tt := range tests {
+		if got := HasCurrencyPrecision(tt.amount, tt.currency); got != tt.want {
+			t.Errorf("HasCurrencyPrecision(%v, %q) = %v, want %v", tt.amount, tt.currency, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Price change statuses, scheduled changes are applied once their effective time has passed. A change fails
+// when the currency of its product changed after it was scheduled.
+const (
+	PriceChangeScheduled = "scheduled"
+	PriceChangeApplied   = "applied"
+	PriceChangeCancelled = "cancelled"
+	PriceChangeFailed    = "failed"
+)
+
+// PriceChange is a change of the base price of a product, or of one of its variants, effective from a point in time
+type PriceChange struct {
+	ID          uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	ProductID   uuid.UUID  `gorm:"type:uuid;not null;index"`
+	VariantID   *uuid.UUID `gorm:"type:uuid;index"`
+	PriceMinor  int64      `gorm:"not null;check:price_minor > 0"`
+	Currency    string     `gorm:"type:char(3);not null"`
+	EffectiveAt time.Time  `gorm:"type:timestamptz;not null;index"`
+	Status      string     `gorm:"type:varchar(20);not null;default:'scheduled';index;check:status IN ('scheduled', 'applied', 'cancelled', 'failed')"`
+	Actor       string     `gorm:"not null"`
+	CreatedAt   time.Time  `gorm:"type:timestamptz;default:now()"`
+}
+
+func (pc *PriceChange) BeforeCreate(tx *gorm.DB) (err error) {
+	pc.ID = uuid.New()
+	return
+}
+
+// Price returns the price the change sets
+func (pc *PriceChange) Price() Money {
+	return Money{Amount: pc.PriceMinor, Currency: pc.Currency}
+}
@@ -0,0 +1,73 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Sales channels a price list applies to
+const (
+	ChannelWeb       = "web"
+	ChannelInStore   = "in_store"
+	ChannelB2BClinic = "b2b_clinic"
+)
+
+// SalesChannels are the allowed sales channels
+var SalesChannels = map[string]bool{
+	ChannelWeb: true, ChannelInStore: true, ChannelB2BClinic: true,
+}
+
+// PriceList holds per-product prices in one currency for one sales channel. The default list of a currency
+// and channel applies unless a caller names another list, such as the negotiated prices of a clinic.
+type PriceList struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	Name      string    `gorm:"type:varchar(100);not null;uniqueIndex"`
+	Currency  string    `gorm:"type:char(3);not null;uniqueIndex:idx_default_price_list,where:is_default"`
+	Channel   string    `gorm:"type:varchar(20);not null;uniqueIndex:idx_default_price_list,where:is_default;check:channel IN ('web', 'in_store', 'b2b_clinic')"`
+	IsDefault bool      `gorm:"default:false"`
+	CreatedAt time.Time `gorm:"type:timestamptz;default:now()"`
+	UpdatedAt time.Time `gorm:"type:timestamptz;default:now()"`
+}
+
+func (pl *PriceList) BeforeCreate(tx *gorm.DB) (err error) {
+	pl.ID = uuid.New()
+	return
+}
+
+// PriceListItem is the price of a product in a price list, in the currency of the list
+type PriceListItem struct {
+	ID          uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	PriceListID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_price_list_product"`
+	ProductID   uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_price_list_product;index"`
+	Product     *Product  `gorm:"foreignKey:ProductID"`
+	PriceMinor  int64     `gorm:"not null;check:price_minor > 0"`
+	Currency    string    `gorm:"-"` // Currency of the price list
+	CreatedAt   time.Time `gorm:"type:timestamptz;default:now()"`
+	UpdatedAt   time.Time `gorm:"type:timestamptz;default:now()"`
+}
+
+func (pli *PriceListItem) BeforeCreate(tx *gorm.DB) (err error) {
+	pli.ID = uuid.New()
+	return
+}
+
+// PriceContext selects the prices a caller sees, either a named price list or the default list of a
+// currency and channel
+type PriceContext struct {
+	PriceListID string
+	Currency    string
+	Channel     string
+}
+
+// IsSet reports whether the caller asked for prices other than the base price
+func (pc PriceContext) IsSet() bool {
+	return pc.PriceListID != "" || pc.Currency != "" || pc.Channel != ""
+}
+
+// AppliedPrice is the price of a product in a price context, from a price list or else its base price
+type AppliedPrice struct {
+	Price       Money
+	PriceListID *uuid.UUID // Unset when the base price applies
+}
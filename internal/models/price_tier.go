@@ -0,0 +1,42 @@
+package models
+
+import (
+	"sort"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PriceTier is the unit price of a product from a quantity up, in the currency of the product.
+// Tiers of 1-9 at 500 and 10 at 450 price 12 units at 450 each.
+type PriceTier struct {
+	ID          uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	ProductID   uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_product_min_quantity"`
+	MinQuantity int       `gorm:"not null;uniqueIndex:idx_product_min_quantity;check:min_quantity > 0"`
+	PriceMinor  int64     `gorm:"not null;check:price_minor > 0"`
+	Currency    string    `gorm:"-"` // Currency of the input price, tiers are stored in the product currency
+}
+
+func (pt *PriceTier) BeforeCreate(tx *gorm.DB) (err error) {
+	pt.ID = uuid.New()
+	return
+}
+
+// SortPriceTiers orders tiers by their minimum quantity
+func SortPriceTiers(tiers []PriceTier) {
+	sort.Slice(tiers, func(i, j int) bool {
+		return tiers[i].MinQuantity < tiers[j].MinQuantity
+	})
+}
+
+// TierFor returns the tier that applies to a quantity, the one with the highest minimum quantity it reaches,
+// or nil when the quantity is below every tier
+func TierFor(tiers []PriceTier, quantity int) *PriceTier {
+	var tier *PriceTier
+	for i := range tiers {
+		if tiers[i].MinQuantity <= quantity && (tier == nil || tiers[i].MinQuantity > tier.MinQuantity) {
+			tier = &tiers[i]
+		}
+	}
+	return tier
+}
@@ -0,0 +1,49 @@
+package models
+
+import "testing"
+
+func TestTierFor(t *testing.T) {
+	tiers := []PriceTier{
+		{MinQuantity: 10, PriceMinor: 450},
+		{MinQuantity: 1, PriceMinor: 500},
+		{MinQuantity: 50, PriceMinor: 400},
+	}
+
+	tests := []struct {
+		tiers     []PriceTier
+		quantity  int
+		wantPrice int64 // 0 when no tier applies
+	}{
+		{tiers, 1, 500},
+		{tiers, 9, 500},
+		{tiers, 10, 450},
+		{tiers, 12, 450},
+		{tiers, 49, 450},
+		{tiers, 50, 400},
+		{tiers, 1000, 400},
+		{tiers, 0, 0},
+		{tiers[:1], 5, 0},
+		{nil, 5, 0},
+	}
+
+	for _, tt := range tests {
+		var got int64
+		if tier := TierFor(tt.tiers, tt.quantity); tier != nil {
+			got = tier.PriceMinor
+		}
+		if got != tt.wantPrice {
+			t.Errorf("TierFor(%v, %d) price = %d, want %d", tt.tiers, tt.quantity, got, tt.wantPrice)
+		}
+	}
+}
+
+func TestSortPriceTiers(t *testing.T) {
+	tiers := []PriceTier{{MinQuantity: 50}, {MinQuantity: 1}, {MinQuantity: 10}}
+	SortPriceTiers(tiers)
+
+	for i, want := range []int{1, 10, 50} {
+		if tiers[i].MinQuantity != want {
+			t.Errorf("tiers[%d].MinQuantity = %d, want %d", i, tiers[i].MinQuantity, want)
+		}
+	}
+}
@@ -7,19 +7,170 @@ import (
 	"gorm.io/gorm"
 )
 
+// Product lifecycle statuses, only active products can be ordered
+const (
+	ProductStatusDraft        = "draft"
+	ProductStatusActive       = "active"
+	ProductStatusDiscontinued = "discontinued"
+	ProductStatusRecalled     = "recalled"
+)
+
+// ProductStatusTransitions lists the statuses a product may move to from each status
+var ProductStatusTransitions = map[string][]string{
+	ProductStatusDraft:        {ProductStatusActive},
+	ProductStatusActive:       {ProductStatusDiscontinued, ProductStatusRecalled},
+	ProductStatusDiscontinued: {ProductStatusActive, ProductStatusRecalled},
+	ProductStatusRecalled:     {ProductStatusActive, ProductStatusDiscontinued},
+}
+
+// Prescription classes, from freely sold to controlled substances
+const (
+	PrescriptionClassOTC          = "otc"
+	PrescriptionClassPharmacyOnly = "pharmacy_only"
+	PrescriptionClassPrescription = "prescription"
+	PrescriptionClassControlled   = "controlled"
+)
+
+// PrescriptionClasses are the allowed prescription classes. Pharmacy-only products are sold behind the
+// counter after a pharmacist consult, without a prescription.
+var PrescriptionClasses = map[string]bool{
+	PrescriptionClassOTC: true, PrescriptionClassPharmacyOnly: true, PrescriptionClassPrescription: true, PrescriptionClassControlled: true,
+}
+
+// ControlledSchedules are the allowed schedules of controlled products
+var ControlledSchedules = map[string]bool{
+	"I": true, "II": true, "III": true, "IV": true, "V": true,
+}
+
+// PrescriptionRequired reports whether products of a prescription class can only be sold with a prescription
+func PrescriptionRequired(prescriptionClass string) bool {
+	return prescriptionClass == PrescriptionClassPrescription || prescriptionClass == PrescriptionClassControlled
+}
+
+// DosageForms are the allowed product dosage forms
+var DosageForms = map[string]bool{
+	"tablet": true, "capsule": true, "caplet": true, "lozenge": true, "powder": true, "granules": true,
+	"solution": true, "suspension": true, "syrup": true, "drops": true, "spray": true, "inhaler": true,
+	"cream": true, "ointment": true, "gel": true, "lotion": true, "patch": true, "suppository": true,
+	"injection": true,
+}
+
+// StrengthUnits are the allowed units of a product or ingredient strength
+var StrengthUnits = map[string]bool{
+	"mg": true, "g": true, "mcg": true, "ml": true, "mg/ml": true, "mg/g": true, "%": true, "iu": true, "iu/ml": true,
+}
+
+// Routes are the allowed routes of administration
+var Routes = map[string]bool{
+	"oral": true, "sublingual": true, "buccal": true, "topical": true, "transdermal": true, "ophthalmic": true,
+	"otic": true, "nasal": true, "inhalation": true, "rectal": true, "vaginal": true, "intravenous": true,
+	"intramuscular": true, "subcutaneous": true,
+}
+
+// StorageConditions are the allowed storage requirements, refrigerated and frozen products ship cold-chain
+var StorageConditions = map[string]bool{
+	"room_temperature": true, "refrigerated": true, "frozen": true,
+}
+
 type Product struct {
 	ID                   uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
 	Name                 string    `gorm:"not null"`
+	Slug                 *string   `gorm:"type:varchar(100);uniqueIndex"`
 	Description          *string
-	Price                float64 `gorm:"not null"`
-	Stock                int     `gorm:"not null;check:stock >= 0"`
-	RequiresPrescription bool    `gorm:"default:false"`
-	ImageURL             *string
-	CreatedAt            time.Time `gorm:"type:timestamptz;default:now()"`
-	UpdatedAt            time.Time `gorm:"type:timestamptz;default:now()"`
+	Price                float64             `gorm:"not null"` // Deprecated: decimal copy of PriceMinor for older readers
+	PriceMinor           int64               `gorm:"not null;default:0"`
+	Currency             string              `gorm:"type:char(3);not null;default:'CAD'"`
+	CostMinor            *int64              `gorm:"check:cost_minor > 0"` // Cost price in the product currency, only shown to admins
+	CostCurrency         string              `gorm:"-"`                    // Currency of the input cost price
+	Stock                int                 `gorm:"not null;check:stock >= 0"`
+	RequiresPrescription bool                `gorm:"default:false"`       // Kept in sync with PrescriptionClass for older readers
+	ImageURL             *string             `gorm:"column:image_url;->"` // Deprecated: read-only, moved to Images by BackfillFromImageURL
+	PrescriptionClass    string              `gorm:"type:varchar(20);not null;default:'otc';index;check:prescription_class IN ('otc', 'pharmacy_only', 'prescription', 'controlled')"`
+	ControlledSchedule   *string             `gorm:"type:varchar(5)"`
+	TaxCategory          *string             `gorm:"type:varchar(20);check:tax_category IN ('zero_rated', 'reduced', 'standard')"` // Unset derives it from the prescription class
+	Status               string              `gorm:"type:varchar(20);not null;default:'active';index;check:status IN ('draft', 'active', 'discontinued', 'recalled')"`
+	BrandID              *uuid.UUID          `gorm:"type:uuid;index"`
+	Brand                *Brand              `gorm:"foreignKey:BrandID"`
+	DosageForm           *string             `gorm:"type:varchar(50);index"`
+	Strength             *float64            `gorm:"type:numeric(12,4)"`
+	StrengthUnit         *string             `gorm:"type:varchar(10)"`
+	Route                *string             `gorm:"type:varchar(20);index"`
+	StorageCondition     *string             `gorm:"type:varchar(20);index;check:storage_condition IN ('room_temperature', 'refrigerated', 'frozen')"`
+	RequiresColdChain    bool                `gorm:"default:false"`
+	IsBundle             bool                `gorm:"default:false"`
+	Components           []BundleComponent   `gorm:"foreignKey:BundleID"`
+	Ingredients          []ProductIngredient `gorm:"foreignKey:ProductID"`
+	Images               []ProductImage      `gorm:"foreignKey:ProductID"`
+	Variants             []ProductVariant    `gorm:"foreignKey:ProductID"`
+	Identifiers          []ProductIdentifier `gorm:"foreignKey:ProductID"`
+	Recalls              []Recall            `gorm:"foreignKey:ProductID"`
+	Relations            []ProductRelation   `gorm:"foreignKey:ProductID"`
+	PriceTiers           []PriceTier         `gorm:"foreignKey:ProductID"`
+	CreatedAt            time.Time           `gorm:"type:timestamptz;default:now()"`
+	UpdatedAt            time.Time           `gorm:"type:timestamptz;default:now()"`
+	DeletedAt            gorm.DeletedAt      `gorm:"type:timestamptz;index"`
+	Locale               string              `gorm:"-"` // Locale of the loaded name and description
+	Replacement          *Product            `gorm:"-"` // Product to buy instead once this one is discontinued
+	RegionAvailability   *RegionAvailability `gorm:"-"` // Set when the product is loaded for a region
+	AppliedPrice         *AppliedPrice       `gorm:"-"` // Set when the product is loaded in a price context
+	SalePrice            *SalePrice          `gorm:"-"` // Set while a promotion discounts the unit price
 }
 
 func (p *Product) BeforeCreate(tx *gorm.DB) (err error) {
 	p.ID = uuid.New()
 	return
 }
+
+// Margin returns what the product earns over its cost at its base price, or nil without a cost price
+func (p *Product) Margin() *Margin {
+	if p.CostMinor == nil {
+		return nil
+	}
+
+	margin := NewMargin(p.UnitPrice(), *p.CostMinor)
+	return &margin
+}
+
+// EffectiveTaxCategory returns the tax category of the product, or the default of its prescription class
+func (p *Product) EffectiveTaxCategory() string {
+	if p.TaxCategory != nil {
+		return *p.TaxCategory
+	}
+	return DefaultTaxCategory(p.PrescriptionClass)
+}
+
+// UnitPrice returns the exact price of the product
+func (p *Product) UnitPrice() Money {
+	return Money{Amount: p.PriceMinor, Currency: p.Currency}
+}
+
+// SyncPrice fills the minor units of a price only given in the deprecated decimal field, and keeps the
+// decimal field in sync with the minor units
+func (p *Product) SyncPrice() {
+	if p.PriceMinor == 0 {
+		p.PriceMinor = MinorUnits(p.Price, p.Currency)
+	}
+	p.Price = p.UnitPrice().Decimal()
+}
+
+// PrimaryImage returns the primary image of the product, if its images are loaded
+func (p *Product) PrimaryImage() *ProductImage {
+	for i := range p.Images {
+		if p.Images[i].IsPrimary {
+			return &p.Images[i]
+		}
+	}
+	return nil
+}
+
+// EffectiveStatus returns the product status, or recalled while a recall of the whole product is active.
+// Only the loaded recalls are considered.
+func (p *Product) EffectiveStatus() string {
+	now := time.Now()
+	for i := range p.Recalls {
+		if p.Recalls[i].VariantID == nil && p.Recalls[i].IsActive(now) {
+			return ProductStatusRecalled
+		}
+	}
+	return p.Status
+}
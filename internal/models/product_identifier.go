@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ProductIdentifier is an external code (barcode, NDC or DIN) that refers to a product or one of its variants.
+// GTIN, UPC and EAN codes are stored as the "gtin" type in their zero-padded GTIN-14 form, and NDCs in their
+// 11-digit 5-4-2 form, so that a code matches no matter which format it was scanned or typed in.
+type ProductIdentifier struct {
+	ID        uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	ProductID uuid.UUID  `gorm:"type:uuid;not null;index"`
+	VariantID *uuid.UUID `gorm:"type:uuid;index"`
+	Type      string     `gorm:"type:varchar(10);not null;uniqueIndex:idx_identifier_type_value;check:type IN ('gtin', 'ndc', 'din')"`
+	Value     string     `gorm:"type:varchar(14);not null;uniqueIndex:idx_identifier_type_value"`
+	CreatedAt time.Time  `gorm:"type:timestamptz;default:now()"`
+}
+
+func (pi *ProductIdentifier) BeforeCreate(tx *gorm.DB) (err error) {
+	pi.ID = uuid.New()
+	return
+}
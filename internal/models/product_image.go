@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ProductImage struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	ProductID uuid.UUID `gorm:"type:uuid;not null;index"`
+	URL       string    `gorm:"column:url;not null"`
+	AltText   *string
+	SortOrder int       `gorm:"not null;default:0"`
+	IsPrimary bool      `gorm:"default:false"`
+	CreatedAt time.Time `gorm:"type:timestamptz;default:now()"`
+}
+
+func (pi *ProductImage) BeforeCreate(tx *gorm.DB) (err error) {
+	pi.ID = uuid.New()
+	return
+}
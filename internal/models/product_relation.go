@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Product relation types
+const (
+	RelationAccessory                = "accessory"
+	RelationFrequentlyBoughtTogether = "frequently_bought_together"
+	RelationAlternative              = "alternative"
+	RelationReplacement              = "replacement"
+)
+
+// RelationTypes are the allowed product relation types
+var RelationTypes = map[string]bool{
+	RelationAccessory: true, RelationFrequentlyBoughtTogether: true, RelationAlternative: true, RelationReplacement: true,
+}
+
+// ProductRelation links a product to a related product shown alongside it. A product has at most one
+// replacement, which customers are pointed to once the product is discontinued.
+type ProductRelation struct {
+	ID               uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	ProductID        uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_product_relation"`
+	RelatedProductID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_product_relation;index"`
+	RelatedProduct   *Product  `gorm:"foreignKey:RelatedProductID"`
+	Type             string    `gorm:"type:varchar(30);not null;uniqueIndex:idx_product_relation;check:type IN ('accessory', 'frequently_bought_together', 'alternative', 'replacement')"`
+	SortOrder        int       `gorm:"not null;default:0"`
+	CreatedAt        time.Time `gorm:"type:timestamptz;default:now()"`
+}
+
+func (pr *ProductRelation) BeforeCreate(tx *gorm.DB) (err error) {
+	pr.ID = uuid.New()
+	return
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ProductSlug is a previous slug of a product, kept so old URLs redirect to the current slug
+type ProductSlug struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	ProductID uuid.UUID `gorm:"type:uuid;not null;index"`
+	Slug      string    `gorm:"type:varchar(100);not null;uniqueIndex"`
+	CreatedAt time.Time `gorm:"type:timestamptz;default:now()"`
+}
+
+func (ps *ProductSlug) BeforeCreate(tx *gorm.DB) (err error) {
+	ps.ID = uuid.New()
+	return
+}
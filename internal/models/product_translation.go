@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DefaultLocale is the language of the content stored on the product itself
+const DefaultLocale = "en"
+
+// ProductTranslation holds the content of a product in another locale, e.g. "fr" or "fr-CA"
+type ProductTranslation struct {
+	ID          uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	ProductID   uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_product_translation_locale"`
+	Locale      string    `gorm:"type:varchar(10);not null;uniqueIndex:idx_product_translation_locale"`
+	Name        string    `gorm:"not null"`
+	Description *string
+	CreatedAt   time.Time `gorm:"type:timestamptz;default:now()"`
+	UpdatedAt   time.Time `gorm:"type:timestamptz;default:now()"`
+}
+
+func (pt *ProductTranslation) BeforeCreate(tx *gorm.DB) (err error) {
+	pt.ID = uuid.New()
+	return
+}
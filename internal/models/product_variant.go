@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ProductVariant struct {
+	ID         uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	ProductID  uuid.UUID `gorm:"type:uuid;not null;index"`
+	SKU        string    `gorm:"column:sku;type:varchar(64);not null;uniqueIndex"`
+	Name       string    `gorm:"not null"`
+	Price      float64   `gorm:"not null"`           // Deprecated: decimal copy of PriceMinor for older readers
+	PriceMinor int64     `gorm:"not null;default:0"` // In the currency of the product
+	Stock      int       `gorm:"not null;check:stock >= 0"`
+	CreatedAt  time.Time `gorm:"type:timestamptz;default:now()"`
+	UpdatedAt  time.Time `gorm:"type:timestamptz;default:now()"`
+}
+
+func (v *ProductVariant) BeforeCreate(tx *gorm.DB) (err error) {
+	v.ID = uuid.New()
+	return
+}
+
+// SyncPrice fills the minor units of a price only given in the deprecated decimal field, and keeps the
+// decimal field in sync with the minor units
+func (v *ProductVariant) SyncPrice(currency string) {
+	if v.PriceMinor == 0 {
+		v.PriceMinor = MinorUnits(v.Price, currency)
+	}
+	v.Price = Money{Amount: v.PriceMinor, Currency: currency}.Decimal()
+}
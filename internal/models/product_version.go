@@ -0,0 +1,178 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Product version actions
+const (
+	ProductActionCreate       = "create"
+	ProductActionUpdate       = "update"
+	ProductActionDelete       = "delete"
+	ProductActionRestore      = "restore"
+	ProductActionStatusChange = "status_change"
+)
+
+// ProductVersion is a snapshot of a product after a change, numbered from 1 per product
+type ProductVersion struct {
+	ID        uuid.UUID            `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	ProductID uuid.UUID            `gorm:"type:uuid;not null;uniqueIndex:idx_product_version"`
+	Version   int                  `gorm:"not null;uniqueIndex:idx_product_version"`
+	Action    string               `gorm:"type:varchar(20);not null;check:action IN ('create', 'update', 'delete', 'restore', 'status_change')"`
+	Actor     string               `gorm:"not null"`
+	Snapshot  string               `gorm:"type:jsonb;not null"`
+	Changes   []ProductFieldChange `gorm:"foreignKey:VersionID"`
+	CreatedAt time.Time            `gorm:"type:timestamptz;default:now()"`
+}
+
+func (pv *ProductVersion) BeforeCreate(tx *gorm.DB) (err error) {
+	pv.ID = uuid.New()
+	return
+}
+
+// NewProductVersion builds the version recording a change of a product, along with the fields that changed
+func NewProductVersion(productID uuid.UUID, action string, actor string, previous *ProductSnapshot, current ProductSnapshot) (*ProductVersion, error) {
+	changes, err := current.Diff(previous)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := json.Marshal(current)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ProductVersion{
+		ProductID: productID,
+		Action:    action,
+		Actor:     actor,
+		Snapshot:  string(snapshot),
+		Changes:   changes,
+	}, nil
+}
+
+// ProductSnapshot decodes the product fields stored with the version
+func (pv *ProductVersion) ProductSnapshot() (ProductSnapshot, error) {
+	var snapshot ProductSnapshot
+	err := json.Unmarshal([]byte(pv.Snapshot), &snapshot)
+	return snapshot, err
+}
+
+// ProductFieldChange is a field changed by a product version, with its old and new values encoded as JSON
+type ProductFieldChange struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	VersionID uuid.UUID `gorm:"type:uuid;not null;index"`
+	Field     string    `gorm:"type:varchar(50);not null"`
+	OldValue  string    `gorm:"not null"`
+	NewValue  string    `gorm:"not null"`
+}
+
+func (pfc *ProductFieldChange) BeforeCreate(tx *gorm.DB) (err error) {
+	pfc.ID = uuid.New()
+	return
+}
+
+// SnapshotFieldCost is the snapshot field of the cost price, whose changes only admins may see
+const SnapshotFieldCost = "cost_minor"
+
+// ProductSnapshot holds the tracked fields of a product. Stock is left out, the inventory log tracks it.
+type ProductSnapshot struct {
+	Name                 string     `json:"name"`
+	Description          *string    `json:"description"`
+	Price                float64    `json:"price"`
+	PriceMinor           int64      `json:"price_minor"`
+	Currency             string     `json:"currency"`
+	RequiresPrescription bool       `json:"requires_prescription"`
+	PrescriptionClass    string     `json:"prescription_class"`
+	ControlledSchedule   *string    `json:"controlled_schedule"`
+	TaxCategory          *string    `json:"tax_category"`
+	CostMinor            *int64     `json:"cost_minor"` // Only shown to admins
+	Status               string     `json:"status"`
+	BrandID              *uuid.UUID `json:"brand_id"`
+	DosageForm           *string    `json:"dosage_form"`
+	Strength             *float64   `json:"strength"`
+	StrengthUnit         *string    `json:"strength_unit"`
+	Route                *string    `json:"route"`
+	StorageCondition     *string    `json:"storage_condition"`
+	RequiresColdChain    bool       `json:"requires_cold_chain"`
+	Deleted              bool       `json:"deleted"`
+}
+
+func NewProductSnapshot(product *Product) ProductSnapshot {
+	return ProductSnapshot{
+		Name:                 product.Name,
+		Description:          product.Description,
+		Price:                product.Price,
+		PriceMinor:           product.PriceMinor,
+		Currency:             product.Currency,
+		RequiresPrescription: product.RequiresPrescription,
+		PrescriptionClass:    product.PrescriptionClass,
+		ControlledSchedule:   product.ControlledSchedule,
+		TaxCategory:          product.TaxCategory,
+		CostMinor:            product.CostMinor,
+		Status:               product.Status,
+		BrandID:              product.BrandID,
+		DosageForm:           product.DosageForm,
+		Strength:             product.Strength,
+		StrengthUnit:         product.StrengthUnit,
+		Route:                product.Route,
+		StorageCondition:     product.StorageCondition,
+		RequiresColdChain:    product.RequiresColdChain,
+		Deleted:              product.DeletedAt.Valid,
+	}
+}
+
+// Diff returns the fields that differ from the previous snapshot, sorted by name. Every field is a change
+// when there is no previous snapshot.
+func (s ProductSnapshot) Diff(previous *ProductSnapshot) ([]ProductFieldChange, error) {
+	current, err := snapshotFields(&s)
+	if err != nil {
+		return nil, err
+	}
+
+	old := map[string]json.RawMessage{}
+	if previous != nil {
+		if old, err = snapshotFields(previous); err != nil {
+			return nil, err
+		}
+	}
+
+	var changes []ProductFieldChange
+	for field, value := range current {
+		oldValue, ok := old[field]
+		if !ok {
+			oldValue = json.RawMessage("null")
+		}
+
+		if !bytes.Equal(oldValue, value) {
+			changes = append(changes, ProductFieldChange{
+				Field:    field,
+				OldValue: string(oldValue),
+				NewValue: string(value),
+			})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+
+	return changes, nil
+}
+
+func snapshotFields(snapshot *ProductSnapshot) (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	err = json.Unmarshal(data, &fields)
+	return fields, err
+}
@@ -0,0 +1,170 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Promotion types
+const (
+	PromotionPercentage = "percentage"  // A percentage off the line
+	PromotionFixed      = "fixed"       // A fixed amount off every unit
+	PromotionBuyXGetY   = "buy_x_get_y" // Of every buy + get units, get units are free
+)
+
+// PromotionTypes are the allowed promotion types
+var PromotionTypes = map[string]bool{
+	PromotionPercentage: true, PromotionFixed: true, PromotionBuyXGetY: true,
+}
+
+// Promotion target types
+const (
+	PromotionTargetProduct = "product"
+	PromotionTargetBrand   = "brand"
+)
+
+// PromotionTargetTypes are the allowed promotion target types
+var PromotionTargetTypes = map[string]bool{
+	PromotionTargetProduct: true, PromotionTargetBrand: true,
+}
+
+// Promotion discounts the products it targets while its date window is open
+type Promotion struct {
+	ID             uuid.UUID         `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	Name           string            `gorm:"type:varchar(100);not null"`
+	Type           string            `gorm:"type:varchar(20);not null;check:type IN ('percentage', 'fixed', 'buy_x_get_y')"`
+	PercentOff     *int              `gorm:"check:percent_off BETWEEN 1 AND 100"`
+	AmountOffMinor *int64            `gorm:"check:amount_off_minor > 0"`
+	Currency       *string           `gorm:"type:char(3)"` // Currency of the fixed amount off
+	BuyQuantity    *int              `gorm:"check:buy_quantity > 0"`
+	GetQuantity    *int              `gorm:"check:get_quantity > 0"`
+	StartsAt       time.Time         `gorm:"type:timestamptz;not null;index"`
+	EndsAt         time.Time         `gorm:"type:timestamptz;not null;index"`
+	Targets        []PromotionTarget `gorm:"foreignKey:PromotionID"`
+	CreatedAt      time.Time         `gorm:"type:timestamptz;default:now()"`
+	UpdatedAt      time.Time         `gorm:"type:timestamptz;default:now()"`
+}
+
+func (p *Promotion) BeforeCreate(tx *gorm.DB) (err error) {
+	p.ID = uuid.New()
+	return
+}
+
+// IsActive reports whether the date window of the promotion is open at a point in time
+func (p *Promotion) IsActive(at time.Time) bool {
+	return !at.Before(p.StartsAt) && at.Before(p.EndsAt)
+}
+
+// TargetsProduct reports whether the promotion applies to a product
+func (p *Promotion) TargetsProduct(product *Product) bool {
+	for _, target := range p.Targets {
+		switch target.TargetType {
+		case PromotionTargetProduct:
+			if target.TargetID == product.ID {
+				return true
+			}
+		case PromotionTargetBrand:
+			if product.BrandID != nil && target.TargetID == *product.BrandID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Discount returns the discount of the promotion on a quantity of units at a unit price, in the currency
+// of the price. Fixed amounts in another currency do not apply.
+func (p *Promotion) Discount(unitPrice Money, quantity int) Money {
+	discount := Money{Currency: unitPrice.Currency}
+	subtotal := unitPrice.Amount * int64(quantity)
+
+	switch p.Type {
+	case PromotionPercentage:
+		if p.PercentOff != nil {
+			discount.Amount = subtotal * int64(*p.PercentOff) / 100
+		}
+	case PromotionFixed:
+		if p.AmountOffMinor != nil && p.Currency != nil && *p.Currency == unitPrice.Currency {
+			discount.Amount = min(*p.AmountOffMinor, unitPrice.Amount) * int64(quantity)
+		}
+	case PromotionBuyXGetY:
+		if p.BuyQuantity != nil && p.GetQuantity != nil {
+			free := quantity / (*p.BuyQuantity + *p.GetQuantity) * *p.GetQuantity
+			discount.Amount = unitPrice.Amount * int64(free)
+		}
+	}
+	return discount
+}
+
+// PromotionTarget is a product or brand a promotion applies to
+type PromotionTarget struct {
+	ID          uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	PromotionID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_promotion_target"`
+	TargetType  string    `gorm:"type:varchar(20);not null;uniqueIndex:idx_promotion_target;check:target_type IN ('product', 'brand')"`
+	TargetID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_promotion_target;index"`
+}
+
+func (pt *PromotionTarget) BeforeCreate(tx *gorm.DB) (err error) {
+	pt.ID = uuid.New()
+	return
+}
+
+// BestPromotion returns the promotion that gives the largest discount on a quantity of a product, promotions
+// do not stack. It returns nil when no promotion discounts the product.
+func BestPromotion(promotions []Promotion, product *Product, unitPrice Money, quantity int) (*Promotion, Money) {
+	var best *Promotion
+	bestDiscount := Money{Currency: unitPrice.Currency}
+	for i := range promotions {
+		if !promotions[i].TargetsProduct(product) {
+			continue
+		}
+
+		if discount := promotions[i].Discount(unitPrice, quantity); discount.Amount > bestDiscount.Amount {
+			best = &promotions[i]
+			bestDiscount = discount
+		}
+	}
+	return best, bestDiscount
+}
+
+// QuoteItem is a line of a basket to quote
+type QuoteItem struct {
+	ProductID uuid.UUID
+	VariantID *uuid.UUID
+	Quantity  int
+}
+
+// AppliedPromotion is the discount a promotion gives on a quote
+type AppliedPromotion struct {
+	PromotionID uuid.UUID
+	Name        string
+	Discount    Money
+}
+
+// QuoteLine is a priced line of a quote
+type QuoteLine struct {
+	Item       QuoteItem
+	UnitPrice  Money
+	PriceTier  *PriceTier // The quantity tier that priced the line, if any
+	Subtotal   Money
+	Discount   Money
+	Total      Money
+	Promotions []AppliedPromotion
+}
+
+// PriceQuote prices a basket in one currency, with the discounts of its promotions
+type PriceQuote struct {
+	Lines      []QuoteLine
+	Subtotal   Money
+	Discount   Money
+	Total      Money
+	Promotions []AppliedPromotion // Total discount of each applied promotion
+}
+
+// SalePrice is the unit price of a product while a promotion discounts it
+type SalePrice struct {
+	Price       Money
+	PromotionID uuid.UUID
+}
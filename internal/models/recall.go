@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RecallSeverityClasses are the recall classes, from class_i (use may cause serious harm) to class_iii (harm unlikely)
+var RecallSeverityClasses = map[string]bool{
+	"class_i": true, "class_ii": true, "class_iii": true,
+}
+
+// Recall withdraws a product from sale from its effective date until it is resolved. A recall with a variant
+// only affects that variant, otherwise it affects the whole product.
+type Recall struct {
+	ID            uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	ProductID     uuid.UUID  `gorm:"type:uuid;not null;index"`
+	VariantID     *uuid.UUID `gorm:"type:uuid;index"`
+	Reason        string     `gorm:"not null"`
+	SeverityClass string     `gorm:"type:varchar(10);not null;check:severity_class IN ('class_i', 'class_ii', 'class_iii')"`
+	EffectiveDate time.Time  `gorm:"type:timestamptz;not null"`
+	ResolvedAt    *time.Time `gorm:"type:timestamptz"`
+	CreatedAt     time.Time  `gorm:"type:timestamptz;default:now()"`
+	UpdatedAt     time.Time  `gorm:"type:timestamptz;default:now()"`
+}
+
+func (r *Recall) BeforeCreate(tx *gorm.DB) (err error) {
+	r.ID = uuid.New()
+	return
+}
+
+// IsActive reports whether the recall is in effect at the given time
+func (r *Recall) IsActive(at time.Time) bool {
+	return r.ResolvedAt == nil && !r.EffectiveDate.After(at)
+}
+
+// RecallImpact is the on-hand stock and the past orders affected by a recall
+type RecallImpact struct {
+	Recall      *Recall
+	OnHandStock int
+	Orders      []InventoryLog
+	TotalOrders int32
+}
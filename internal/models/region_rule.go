@@ -0,0 +1,93 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Region rules, overriding how a product may be sold in a region
+const (
+	RegionRuleNotSold         = "not_sold"
+	RegionRuleOTC             = "otc"
+	RegionRulePrescription    = "prescription"
+	RegionRuleQuantityLimited = "quantity_limited"
+)
+
+// RegionRules are the allowed region rules
+var RegionRules = map[string]bool{
+	RegionRuleNotSold: true, RegionRuleOTC: true, RegionRulePrescription: true, RegionRuleQuantityLimited: true,
+}
+
+// ProductRegionRule overrides the sale of a product in a region, an ISO 3166-2 subdivision such as "CA-ON"
+// or a whole country such as "CA". A subdivision rule takes precedence over the rule of its country.
+type ProductRegionRule struct {
+	ID          uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	ProductID   uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_product_region"`
+	Region      string    `gorm:"type:varchar(10);not null;uniqueIndex:idx_product_region;index"`
+	Rule        string    `gorm:"type:varchar(20);not null;check:rule IN ('not_sold', 'otc', 'prescription', 'quantity_limited')"`
+	MaxQuantity *int      `gorm:"check:max_quantity > 0"`
+	CreatedAt   time.Time `gorm:"type:timestamptz;default:now()"`
+	UpdatedAt   time.Time `gorm:"type:timestamptz;default:now()"`
+}
+
+func (prr *ProductRegionRule) BeforeCreate(tx *gorm.DB) (err error) {
+	prr.ID = uuid.New()
+	return
+}
+
+// RegionAvailability is how a product may be sold in a region once its region rules are applied
+type RegionAvailability struct {
+	Region               string
+	Sellable             bool
+	PrescriptionClass    string
+	RequiresPrescription bool
+	MaxQuantity          *int
+	Rule                 *ProductRegionRule
+}
+
+// RegionFallbacks lists the regions whose rules apply to a region, most specific first: "CA-ON" falls back to "CA"
+func RegionFallbacks(region string) []string {
+	if country, _, found := strings.Cut(region, "-"); found {
+		return []string{region, country}
+	}
+	return []string{region}
+}
+
+// NewRegionAvailability applies the most specific of the rules of a product for a region
+func NewRegionAvailability(product *Product, region string, rules []ProductRegionRule) RegionAvailability {
+	availability := RegionAvailability{
+		Region:            region,
+		Sellable:          product.EffectiveStatus() == ProductStatusActive,
+		PrescriptionClass: product.PrescriptionClass,
+	}
+
+	for _, fallback := range RegionFallbacks(region) {
+		for i := range rules {
+			if rules[i].ProductID == product.ID && rules[i].Region == fallback && availability.Rule == nil {
+				availability.Rule = &rules[i]
+			}
+		}
+	}
+
+	if availability.Rule != nil {
+		switch availability.Rule.Rule {
+		case RegionRuleNotSold:
+			availability.Sellable = false
+		case RegionRuleOTC:
+			availability.PrescriptionClass = PrescriptionClassOTC
+		case RegionRulePrescription:
+			// Controlled products stay controlled, everything else needs a prescription
+			if availability.PrescriptionClass != PrescriptionClassControlled {
+				availability.PrescriptionClass = PrescriptionClassPrescription
+			}
+		case RegionRuleQuantityLimited:
+			availability.MaxQuantity = availability.Rule.MaxQuantity
+		}
+	}
+
+	availability.RequiresPrescription = PrescriptionRequired(availability.PrescriptionClass)
+	return availability
+}
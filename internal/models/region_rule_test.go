@@ -0,0 +1,75 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestNewRegionAvailability(t *testing.T) {
+	productID := uuid.New()
+	limit := 2
+	rule := func(region string, kind string) ProductRegionRule {
+		r := ProductRegionRule{ProductID: productID, Region: region, Rule: kind}
+		if kind == RegionRuleQuantityLimited {
+			r.MaxQuantity = &limit
+		}
+		return r
+	}
+
+	tests := []struct {
+		name              string
+		status            string
+		prescriptionClass string
+		region            string
+		rules             []ProductRegionRule
+		wantSellable      bool
+		wantClass         string
+		wantPrescription  bool
+		wantMaxQuantity   *int
+		wantRuleRegion    string
+	}{
+		{"no rules", ProductStatusActive, PrescriptionClassOTC, "CA-ON", nil, true, PrescriptionClassOTC, false, nil, ""},
+		{"inactive product", ProductStatusDraft, PrescriptionClassOTC, "CA", nil, false, PrescriptionClassOTC, false, nil, ""},
+		{"not sold", ProductStatusActive, PrescriptionClassOTC, "CA", []ProductRegionRule{rule("CA", RegionRuleNotSold)}, false, PrescriptionClassOTC, false, nil, "CA"},
+		{"country rule applies to subdivision", ProductStatusActive, PrescriptionClassOTC, "CA-QC", []ProductRegionRule{rule("CA", RegionRuleNotSold)}, false, PrescriptionClassOTC, false, nil, "CA"},
+		{"subdivision rule wins", ProductStatusActive, PrescriptionClassOTC, "CA-QC", []ProductRegionRule{rule("CA", RegionRuleNotSold), rule("CA-QC", RegionRuleOTC)}, true, PrescriptionClassOTC, false, nil, "CA-QC"},
+		{"other subdivision ignored", ProductStatusActive, PrescriptionClassOTC, "CA-ON", []ProductRegionRule{rule("CA-QC", RegionRuleNotSold)}, true, PrescriptionClassOTC, false, nil, ""},
+		{"otc relaxes prescription", ProductStatusActive, PrescriptionClassPrescription, "US", []ProductRegionRule{rule("US", RegionRuleOTC)}, true, PrescriptionClassOTC, false, nil, "US"},
+		{"prescription required", ProductStatusActive, PrescriptionClassOTC, "US", []ProductRegionRule{rule("US", RegionRulePrescription)}, true, PrescriptionClassPrescription, true, nil, "US"},
+		{"controlled stays controlled", ProductStatusActive, PrescriptionClassControlled, "US", []ProductRegionRule{rule("US", RegionRulePrescription)}, true, PrescriptionClassControlled, true, nil, "US"},
+		{"quantity limited", ProductStatusActive, PrescriptionClassOTC, "GB", []ProductRegionRule{rule("GB", RegionRuleQuantityLimited)}, true, PrescriptionClassOTC, false, &limit, "GB"},
+		{"rule of another product ignored", ProductStatusActive, PrescriptionClassOTC, "CA", []ProductRegionRule{{ProductID: uuid.New(), Region: "CA", Rule: RegionRuleNotSold}}, true, PrescriptionClassOTC, false, nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			product := &Product{ID: productID, Status: tt.status, PrescriptionClass: tt.prescriptionClass}
+			got := NewRegionAvailability(product, tt.region, tt.rules)
+
+			if got.Region != tt.region {
+				t.Errorf("Region = %q, want %q", got.Region, tt.region)
+			}
+			if got.Sellable != tt.wantSellable {
+				t.Errorf("Sellable = %v, want %v", got.Sellable, tt.wantSellable)
+			}
+			if got.PrescriptionClass != tt.wantClass {
+				t.Errorf("PrescriptionClass = %q, want %q", got.PrescriptionClass, tt.wantClass)
+			}
+			if got.RequiresPrescription != tt.wantPrescription {
+				t.Errorf("RequiresPrescription = %v, want %v", got.RequiresPrescription, tt.wantPrescription)
+			}
+			if (got.MaxQuantity == nil) != (tt.wantMaxQuantity == nil) || (got.MaxQuantity != nil && *got.MaxQuantity != *tt.wantMaxQuantity) {
+				t.Errorf("MaxQuantity = %v, want %v", got.MaxQuantity, tt.wantMaxQuantity)
+			}
+
+			var ruleRegion string
+			if got.Rule != nil {
+				ruleRegion = got.Rule.Region
+			}
+			if ruleRegion != tt.wantRuleRegion {
+				t.Errorf("Rule region = %q, want %q", ruleRegion, tt.wantRuleRegion)
+			}
+		})
+	}
+}
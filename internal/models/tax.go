@@ -0,0 +1,94 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Tax categories. Zero-rated products are taxable at a rate of zero in every region.
+const (
+	TaxCategoryZeroRated = "zero_rated"
+	TaxCategoryReduced   = "reduced"
+	TaxCategoryStandard  = "standard"
+)
+
+// TaxCategories are the allowed tax categories
+var TaxCategories = map[string]bool{
+	TaxCategoryZeroRated: true, TaxCategoryReduced: true, TaxCategoryStandard: true,
+}
+
+// DefaultTaxCategory is the tax category of products without one: prescription drugs are zero-rated and
+// everything sold without a prescription is standard-rated
+func DefaultTaxCategory(prescriptionClass string) string {
+	if PrescriptionRequired(prescriptionClass) {
+		return TaxCategoryZeroRated
+	}
+	return TaxCategoryStandard
+}
+
+// TaxRate is the rate of a tax category in a region, an ISO 3166-2 subdivision such as "CA-ON" or a whole
+// country such as "CA". A subdivision rate is the combined rate of the subdivision and replaces the rate of
+// its country. Rates are in thousandths of a percent, 14.975% is 14975.
+type TaxRate struct {
+	ID               uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	Region           string    `gorm:"type:varchar(10);not null;uniqueIndex:idx_region_tax_category"`
+	TaxCategory      string    `gorm:"type:varchar(20);not null;uniqueIndex:idx_region_tax_category;check:tax_category IN ('reduced', 'standard')"`
+	RateMilliPercent int       `gorm:"not null;check:rate_milli_percent BETWEEN 0 AND 100000"`
+	CreatedAt        time.Time `gorm:"type:timestamptz;default:now()"`
+	UpdatedAt        time.Time `gorm:"type:timestamptz;default:now()"`
+}
+
+func (tr *TaxRate) BeforeCreate(tx *gorm.DB) (err error) {
+	tr.ID = uuid.New()
+	return
+}
+
+// Tax returns the tax on an amount at the rate, rounded half up to the minor unit
+func (tr *TaxRate) Tax(amount Money) Money {
+	return Money{Amount: (amount.Amount*int64(tr.RateMilliPercent) + 50000) / 100000, Currency: amount.Currency}
+}
+
+// TaxRateFor returns the most specific rate of a tax category for a region, zero for zero-rated products,
+// or nil when the region has no rate for the category
+func TaxRateFor(rates []TaxRate, region string, taxCategory string) *TaxRate {
+	if taxCategory == TaxCategoryZeroRated {
+		return &TaxRate{Region: region, TaxCategory: taxCategory}
+	}
+
+	for _, fallback := range RegionFallbacks(region) {
+		for i := range rates {
+			if rates[i].Region == fallback && rates[i].TaxCategory == taxCategory {
+				return &rates[i]
+			}
+		}
+	}
+	return nil
+}
+
+// TaxLine is the tax on a line of a quote, charged on the line total after discounts
+type TaxLine struct {
+	QuoteLine
+	TaxCategory      string
+	RateMilliPercent int
+	Tax              Money
+}
+
+// TaxCategoryTotal is the tax charged on the lines of one tax category
+type TaxCategoryTotal struct {
+	TaxCategory      string
+	RateMilliPercent int
+	Taxable          Money
+	Tax              Money
+}
+
+// TaxCalculation is the tax on a basket in a region
+type TaxCalculation struct {
+	Region     string
+	Lines      []TaxLine
+	Categories []TaxCategoryTotal
+	Subtotal   Money // Total of the lines after discounts, before tax
+	Tax        Money
+	Total      Money
+}
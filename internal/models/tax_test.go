@@ -0,0 +1,76 @@
+package models
+
+import "testing"
+
+func TestTaxRateTax(t *testing.T) {
+	tests := []struct {
+		amount           int64
+		rateMilliPercent int
+		want             int64
+	}{
+		{1000, 13000, 130},
+		{1999, 14975, 299}, // 299.35
+		{2000, 14975, 300}, // 299.5
+		{1000, 50, 1},      // 0.5
+		{999, 50, 0},       // 0.4995
+		{1001, 5000, 50},   // 50.05
+		{1010, 5000, 51},   // 50.5
+		{123456789, 20000, 24691358},
+		{0, 13000, 0},
+		{1000, 0, 0},
+		{1000, 100000, 1000},
+	}
+
+	for _, tt := range tests {
+		rate := &TaxRate{RateMilliPercent: tt.rateMilliPercent}
+		got := rate.Tax(Money{Amount: tt.amount, Currency: "CAD"})
+		if got.Amount != tt.want || got.Currency != "CAD" {
+			t.Errorf("Tax(%d) at %d = %v, want %d CAD", tt.amount, tt.rateMilliPercent, got, tt.want)
+		}
+	}
+}
+
+func TestTaxRateFor(t *testing.T) {
+	rates := []TaxRate{
+		{Region: "CA", TaxCategory: TaxCategoryStandard, RateMilliPercent: 5000},
+		{Region: "CA-ON", TaxCategory: TaxCategoryStandard, RateMilliPercent: 13000},
+		{Region: "CA-QC", TaxCategory: TaxCategoryReduced, RateMilliPercent: 9975},
+		{Region: "GB", TaxCategory: TaxCategoryReduced, RateMilliPercent: 5000},
+	}
+
+	tests := []struct {
+		name        string
+		region      string
+		taxCategory string
+		wantRegion  string // Empty when there is no rate
+		wantRate    int
+	}{
+		{"subdivision rate", "CA-ON", TaxCategoryStandard, "CA-ON", 13000},
+		{"country rate for subdivision", "CA-QC", TaxCategoryStandard, "CA", 5000},
+		{"country rate", "CA", TaxCategoryStandard, "CA", 5000},
+		{"subdivision rate of category", "CA-QC", TaxCategoryReduced, "CA-QC", 9975},
+		{"no rate for category", "CA-ON", TaxCategoryReduced, "", 0},
+		{"no rate for region", "US-NY", TaxCategoryStandard, "", 0},
+		{"country rate not replaced by other subdivision", "GB-SCT", TaxCategoryReduced, "GB", 5000},
+		{"zero-rated", "CA-ON", TaxCategoryZeroRated, "CA-ON", 0},
+		{"zero-rated without rates", "US-NY", TaxCategoryZeroRated, "US-NY", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TaxRateFor(rates, tt.region, tt.taxCategory)
+			if tt.wantRegion == "" {
+				if got != nil {
+					t.Errorf("TaxRateFor(%q, %q) = %+v, want nil", tt.region, tt.taxCategory, got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatalf("TaxRateFor(%q, %q) = nil, want rate of %s", tt.region, tt.taxCategory, tt.wantRegion)
+			}
+			if got.Region != tt.wantRegion || got.RateMilliPercent != tt.wantRate {
+				t.Errorf("TaxRateFor(%q, %q) = %s at %d, want %s at %d", tt.region, tt.taxCategory, got.Region, got.RateMilliPercent, tt.wantRegion, tt.wantRate)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package repositories
+
+import (
+	"fmt"
+
+	"github.com/PharmaKart/product-svc/internal/models"
+	"github.com/PharmaKart/product-svc/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type BrandRepository interface {
+	CreateBrand(brand *models.Brand) (string, error)
+	GetBrand(id string) (*models.Brand, error)
+	GetBrandByName(name string) (*models.Brand, error)
+	ListBrands(manufacturerID string, page, limit int32) ([]models.Brand, int32, error)
+	UpdateBrand(brand *models.Brand) error
+	DeleteBrand(id string) error
+}
+
+type brandRepository struct {
+	db *gorm.DB
+}
+
+func NewBrandRepository(db *gorm.DB) BrandRepository {
+	return &brandRepository{db}
+}
+
+func (r *brandRepository) CreateBrand(brand *models.Brand) (string, error) {
+	existingBrand, err := r.GetBrandByName(brand.Name)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); !ok || appErr.Type != errors.NotFoundError {
+			return "", err
+		}
+	}
+
+	if existingBrand != nil {
+		return "", errors.NewConflictError(fmt.Sprintf("Brand with name '%s' already exists", brand.Name))
+	}
+
+	if err := r.db.Omit(clause.Associations).Create(brand).Error; err != nil {
+		return "", errors.NewInternalError(err)
+	}
+	return brand.ID.String(), nil
+}
+
+func (r *brandRepository) GetBrand(id string) (*models.Brand, error) {
+	var brand models.Brand
+	err := r.db.Preload("Manufacturer").Where("id = ?", id).First(&brand).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError(fmt.Sprintf("Brand with ID '%s' not found", id))
+		}
+		return nil, errors.NewInternalError(err)
+	}
+	return &brand, nil
+}
+
+func (r *brandRepository) GetBrandByName(name string) (*models.Brand, error) {
+	var brand models.Brand
+	err := r.db.Preload("Manufacturer").Where("name = ?", name).First(&brand).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError(fmt.Sprintf("Brand with name '%s' not found", name))
+		}
+		return nil, errors.NewInternalError(err)
+	}
+	return &brand, nil
+}
+
+func (r *brandRepository) ListBrands(manufacturerID string, page, limit int32) ([]models.Brand, int32, error) {
+	var brands []models.Brand
+	var total int64
+
+	query := r.db.Model(&models.Brand{}).Order("name asc")
+	if manufacturerID != "" {
+		query = query.Where("manufacturer_id = ?", manufacturerID)
+	}
+
+	err := query.Count(&total).Error
+	if err != nil {
+		return nil, 0, errors.NewInternalError(err)
+	}
+
+	if limit > 0 {
+		offset := max(int((page-1)*limit), 0)
+		query = query.Offset(offset).Limit(int(limit))
+	}
+
+	err = query.Preload("Manufacturer").Find(&brands).Error
+	if err != nil {
+		return nil, 0, errors.NewInternalError(err)
+	}
+
+	return brands, int32(total), nil
+}
+
+func (r *brandRepository) UpdateBrand(brand *models.Brand) error {
+	existingBrand, err := r.GetBrandByName(brand.Name)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); !ok || appErr.Type != errors.NotFoundError {
+			return err
+		}
+	}
+
+	if existingBrand != nil && existingBrand.ID != brand.ID {
+		return errors.NewConflictError(fmt.Sprintf("Brand with name '%s' already exists", brand.Name))
+	}
+
+	if err := r.db.Omit(clause.Associations).Save(brand).Error; err != nil {
+		return errors.NewInternalError(err)
+	}
+
+	return nil
+}
+
+func (r *brandRepository) DeleteBrand(id string) error {
+	_, err := r.GetBrand(id)
+	if err != nil {
+		return err
+	}
+
+	var productCount int64
+	// Soft deleted products count too, they keep their brand when restored
+	if err := r.db.Unscoped().Model(&models.Product{}).Where("brand_id = ?", id).Count(&productCount).Error; err != nil {
+		return errors.NewInternalError(err)
+	}
+
+	if productCount > 0 {
+		return errors.NewConflictError(fmt.Sprintf("Brand with ID '%s' is still assigned to %d product(s)", id, productCount))
+	}
+
+	if err := r.db.Where("id = ?", id).Delete(&models.Brand{}).Error; err != nil {
+		return errors.NewInternalError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package repositories
+
+import (
+	"fmt"
+
+	"github.com/PharmaKart/product-svc/internal/models"
+	"github.com/PharmaKart/product-svc/pkg/errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type BundleRepository interface {
+	ReplaceComponents(bundleID uuid.UUID, components []models.BundleComponent) error
+	ListComponents(bundleIDs []uuid.UUID) ([]models.BundleComponent, error)
+	IsComponent(productID uuid.UUID) (bool, error)
+	UpdateComponentStock(logs []models.InventoryLog) error
+}
+
+type bundleRepository struct {
+	db *gorm.DB
+}
+
+func NewBundleRepository(db *gorm.DB) BundleRepository {
+	return &bundleRepository{db}
+}
+
+// ReplaceComponents swaps the components of a bundle. A product with no components is no longer a bundle.
+func (r *bundleRepository) ReplaceComponents(bundleID uuid.UUID, components []models.BundleComponent) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("bundle_id = ?", bundleID).Delete(&models.BundleComponent{}).Error; err != nil {
+			return err
+		}
+
+		for i := range components {
+			components[i].BundleID = bundleID
+			if err := tx.Omit(clause.Associations).Create(&components[i]).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&models.Product{}).Where("id = ?", bundleID).Update("is_bundle", len(components) > 0).Error
+	})
+	if err != nil {
+		return errors.NewInternalError(err)
+	}
+
+	return nil
+}
+
+// ListComponents returns the components of the bundles with the component products and variants loaded
+func (r *bundleRepository) ListComponents(bundleIDs []uuid.UUID) ([]models.BundleComponent, error) {
+	var components []models.BundleComponent
+	if len(bundleIDs) == 0 {
+		return components, nil
+	}
+
+	err := r.db.Preload("Component.Recalls", activeRecalls).
+		Preload("Variant").
+		Where("bundle_id IN ?", bundleIDs).
+		Order("id asc").
+		Find(&components).Error
+	if err != nil {
+		return nil, errors.NewInternalError(err)
+	}
+	return components, nil
+}
+
+// IsComponent reports whether the product is a component of any bundle
+func (r *bundleRepository) IsComponent(productID uuid.UUID) (bool, error) {
+	var count int64
+	if err := r.db.Model(&models.BundleComponent{}).Where("component_id = ?", productID).Count(&count).Error; err != nil {
+		return false, errors.NewInternalError(err)
+	}
+	return count > 0, nil
+}
+
+// UpdateComponentStock applies the stock change of every log to its product or variant and records the logs,
+// all or nothing. It fails when a change would take the stock of a component below zero.
+func (r *bundleRepository) UpdateComponentStock(logs []models.InventoryLog) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for i := range logs {
+			query := tx.Model(&models.Product{}).Where("id = ?", logs[i].ProductID)
+			if logs[i].VariantID != nil {
+				query = tx.Model(&models.ProductVariant{}).Where("id = ?", *logs[i].VariantID)
+			}
+
+			result := query.Where("stock + ? >= 0", logs[i].QuantityChange).Update("stock", gorm.Expr("stock + ?", logs[i].QuantityChange))
+			if result.Error != nil {
+				return result.Error
+			}
+
+			if result.RowsAffected == 0 {
+				return errors.NewBadRequestError(fmt.Sprintf("Insufficient stock of component with ID '%s'", logs[i].ProductID))
+			}
+
+			if err := tx.Create(&logs[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if _, ok := errors.IsAppError(err); ok {
+			return err
+		}
+		return errors.NewInternalError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package repositories
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/PharmaKart/product-svc/internal/models"
+	"github.com/PharmaKart/product-svc/pkg/errors"
+)
+
+// Limits of a filter expression, so a caller cannot make the database evaluate an arbitrarily large one
+const (
+	maxFilterDepth      = 5
+	maxFilterConditions = 50
+)
+
+var allowedOperators = map[string]string{
+	"eq":      "=",           // Equal to
+	"neq":     "!=",          // Not equal to
+	"gt":      ">",           // Greater than
+	"gte":     ">=",          // Greater than or equal to
+	"lt":      "<",           // Less than
+	"lte":     "<=",          // Less than or equal to
+	"like":    "LIKE",        // LIKE for pattern matching
+	"ilike":   "ILIKE",       // Case insensitive LIKE (for PostgreSQL)
+	"in":      "IN",          // IN for multiple values
+	"null":    "IS NULL",     // IS NULL check
+	"notnull": "IS NOT NULL", // IS NOT NULL check
+}
+
+// filterSQL builds the condition of a filter expression. Columns and operators are checked against the
+// whitelists and values are only passed as arguments, so the condition is safe to add to a query.
+func filterSQL(expression models.FilterExpression, allowedColumns map[string]bool) (string, []interface{}, error) {
+	conditions := 0
+	return filterExpressionSQL(expression, allowedColumns, 1, &conditions)
+}
+
+func filterExpressionSQL(expression models.FilterExpression, allowedColumns map[string]bool, depth int, conditions *int) (string, []interface{}, error) {
+	if depth > maxFilterDepth {
+		return "", nil, errors.NewBadRequestError(fmt.Sprintf("filter groups cannot be nested more than %d deep", maxFilterDepth))
+	}
+
+	joiner := " AND "
+	switch strings.ToLower(expression.Operator) {
+	case "", models.FilterAnd:
+	case models.FilterOr:
+		joiner = " OR "
+	default:
+		return "", nil, errors.NewBadRequestError("invalid filter expression operator: " + expression.Operator)
+	}
+
+	var parts []string
+	var args []interface{}
+	for _, filter := range expression.Conditions {
+		*conditions++
+		if *conditions > maxFilterConditions {
+			return "", nil, errors.NewBadRequestError(fmt.Sprintf("filters cannot have more than %d conditions", maxFilterConditions))
+		}
+
+		part, partArgs, err := filterConditionSQL(filter, allowedColumns)
+		if err != nil {
+			return "", nil, err
+		}
+		parts = append(parts, part)
+		args = append(args, partArgs...)
+	}
+
+	for _, group := range expression.Groups {
+		if group.IsEmpty() {
+			continue
+		}
+
+		part, partArgs, err := filterExpressionSQL(group, allowedColumns, depth+1, conditions)
+		if err != nil {
+			return "", nil, err
+		}
+		parts = append(parts, part)
+		args = append(args, partArgs...)
+	}
+
+	return "(" + strings.Join(parts, joiner) + ")", args, nil
+}
+
+func filterConditionSQL(filter models.Filter, allowedColumns map[string]bool) (string, []interface{}, error) {
+	if _, allowed := allowedColumns[filter.Column]; !allowed {
+		return "", nil, errors.NewBadRequestError("invalid filter column: " + filter.Column)
+	}
+
+	op, allowed := allowedOperators[filter.Operator]
+	if !allowed {
+		return "", nil, errors.NewBadRequestError("invalid filter operator: " + filter.Operator)
+	}
+
+	switch filter.Operator {
+	case "like", "ilike":
+		return filter.Column + " " + op + " ?", []interface{}{"%" + filter.Value + "%"}, nil
+	case "in":
+		values := strings.Split(filter.Value, ",")
+		return filter.Column + " " + op + " (?)", []interface{}{values}, nil
+	case "null", "notnull":
+		return filter.Column + " " + op, nil, nil
+	default:
+		return filter.Column + " " + op + " ?", []interface{}{filter.Value}, nil
+	}
+}
@@ -0,0 +1,144 @@
+package repositories
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/PharmaKart/product-svc/internal/models"
+	"github.com/PharmaKart/product-svc/pkg/errors"
+)
+
+var testFilterColumns = map[string]bool{"name": true, "price": true, "stock": true, "brand_id": true}
+
+// nestedFilter returns an expression with one condition in a group at the given depth
+func nestedFilter(depth int) models.FilterExpression {
+	expression := models.FilterExpression{Conditions: []models.Filter{{Column: "price", Operator: "gt", Value: "10"}}}
+	for i := 1; i < depth; i++ {
+		expression = models.FilterExpression{Groups: []models.FilterExpression{expression}}
+	}
+	return expression
+}
+
+// conditionsFilter returns an expression of n conditions, half of them in a nested group
+func conditionsFilter(n int) models.FilterExpression {
+	expression := models.FilterExpression{Groups: []models.FilterExpression{{Operator: models.FilterOr}}}
+	for i := 0; i < n; i++ {
+		condition := models.Filter{Column: "stock", Operator: "gte", Value: "1"}
+		if i%2 == 0 {
+			expression.Conditions = append(expression.Conditions, condition)
+		} else {
+			expression.Groups[0].Conditions = append(expression.Groups[0].Conditions, condition)
+		}
+	}
+	return expression
+}
+
+func TestFilterSQL(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression models.FilterExpression
+		wantSQL    string
+		wantArgs   []interface{}
+	}{
+		{
+			"single condition",
+			models.FilterExpression{Conditions: []models.Filter{{Column: "price", Operator: "lte", Value: "20"}}},
+			"(price <= ?)", []interface{}{"20"},
+		},
+		{
+			"and by default",
+			models.FilterExpression{Conditions: []models.Filter{{Column: "price", Operator: "gt", Value: "5"}, {Column: "stock", Operator: "neq", Value: "0"}}},
+			"(price > ? AND stock != ?)", []interface{}{"5", "0"},
+		},
+		{
+			"or",
+			models.FilterExpression{Operator: "OR", Conditions: []models.Filter{{Column: "price", Operator: "eq", Value: "5"}, {Column: "price", Operator: "eq", Value: "6"}}},
+			"(price = ? OR price = ?)", []interface{}{"5", "6"},
+		},
+		{
+			"operators",
+			models.FilterExpression{Conditions: []models.Filter{
+				{Column: "name", Operator: "ilike", Value: "aspirin"},
+				{Column: "brand_id", Operator: "in", Value: "a,b"},
+				{Column: "brand_id", Operator: "notnull"},
+			}},
+			"(name ILIKE ? AND brand_id IN (?) AND brand_id IS NOT NULL)", []interface{}{"%aspirin%", []string{"a", "b"}},
+		},
+		{
+			"nested groups",
+			models.FilterExpression{
+				Conditions: []models.Filter{{Column: "stock", Operator: "gt", Value: "0"}},
+				Groups: []models.FilterExpression{{
+					Operator:   models.FilterOr,
+					Conditions: []models.Filter{{Column: "price", Operator: "lt", Value: "10"}, {Column: "name", Operator: "like", Value: "kit"}},
+				}},
+			},
+			"(stock > ? AND (price < ? OR name LIKE ?))", []interface{}{"0", "10", "%kit%"},
+		},
+		{
+			"empty groups skipped",
+			models.FilterExpression{
+				Conditions: []models.Filter{{Column: "stock", Operator: "null"}},
+				Groups:     []models.FilterExpression{{Operator: models.FilterOr}, {Groups: []models.FilterExpression{{}}}},
+			},
+			"(stock IS NULL)", nil,
+		},
+		{"deepest group", nestedFilter(maxFilterDepth), "(((((price > ?)))))", []interface{}{"10"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args, err := filterSQL(tt.expression, testFilterColumns)
+			if err != nil {
+				t.Fatalf("filterSQL() error = %v", err)
+			}
+			if sql != tt.wantSQL {
+				t.Errorf("filterSQL() sql = %q, want %q", sql, tt.wantSQL)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("filterSQL() args = %v, want %v", args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestFilterSQLLimits(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression models.FilterExpression
+		wantErr    string // Empty when the expression is valid
+	}{
+		{"too deep", nestedFilter(maxFilterDepth + 1), "filter groups cannot be nested more than 5 deep"},
+		{"most conditions", conditionsFilter(maxFilterConditions), ""},
+		{"too many conditions", conditionsFilter(maxFilterConditions + 1), "filters cannot have more than 50 conditions"},
+		{"disallowed column", models.FilterExpression{Conditions: []models.Filter{{Column: "cost_minor", Operator: "gt", Value: "0"}}}, "invalid filter column: cost_minor"},
+		{"column injection", models.FilterExpression{Conditions: []models.Filter{{Column: "price; DROP TABLE products", Operator: "eq", Value: "0"}}}, "invalid filter column: price; DROP TABLE products"},
+		{"invalid operator", models.FilterExpression{Conditions: []models.Filter{{Column: "price", Operator: "between", Value: "0"}}}, "invalid filter operator: between"},
+		{"invalid expression operator", models.FilterExpression{Operator: "xor", Conditions: []models.Filter{{Column: "price", Operator: "eq", Value: "0"}}}, "invalid filter expression operator: xor"},
+		{"invalid nested condition", models.FilterExpression{Groups: []models.FilterExpression{{Conditions: []models.Filter{{Column: "secret", Operator: "eq"}}}}}, "invalid filter column: secret"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, _, err := filterSQL(tt.expression, testFilterColumns)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("filterSQL() error = %v", err)
+				}
+				if got := strings.Count(sql, "?"); got != maxFilterConditions {
+					t.Errorf("filterSQL() has %d placeholders, want %d", got, maxFilterConditions)
+				}
+				return
+			}
+
+			appErr, ok := errors.IsAppError(err)
+			if !ok {
+				t.Fatalf("filterSQL() error = %v, want a bad request", err)
+			}
+			if appErr.Type != errors.BadRequestError || appErr.Message != tt.wantErr {
+				t.Errorf("filterSQL() error = %s %q, want %s %q", appErr.Type, appErr.Message, errors.BadRequestError, tt.wantErr)
+			}
+		})
+	}
+}
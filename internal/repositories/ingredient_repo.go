@@ -0,0 +1,107 @@
+package repositories
+
+import (
+	"strings"
+
+	"github.com/PharmaKart/product-svc/internal/models"
+	"github.com/PharmaKart/product-svc/pkg/errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IngredientRepository interface {
+	ReplaceProductIngredients(productID uuid.UUID, ingredients []models.ProductIngredient) error
+	ListIngredientsByProductID(productID string) ([]models.ProductIngredient, error)
+	FindEquivalentProducts(productID uuid.UUID, dosageForm string, currency string, ingredients []models.ProductIngredient, limit int32) ([]models.Product, error)
+}
+
+type ingredientRepository struct {
+	db *gorm.DB
+}
+
+func NewIngredientRepository(db *gorm.DB) IngredientRepository {
+	return &ingredientRepository{db}
+}
+
+// ReplaceProductIngredients swaps the ingredients of a product, creating unknown active ingredients by name
+func (r *ingredientRepository) ReplaceProductIngredients(productID uuid.UUID, ingredients []models.ProductIngredient) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("product_id = ?", productID).Delete(&models.ProductIngredient{}).Error; err != nil {
+			return err
+		}
+
+		for i := range ingredients {
+			ingredient := models.ActiveIngredient{Name: ingredients[i].Ingredient.Name}
+			if err := tx.Where("name = ?", ingredient.Name).FirstOrCreate(&ingredient).Error; err != nil {
+				return err
+			}
+
+			ingredients[i].ProductID = productID
+			ingredients[i].IngredientID = ingredient.ID
+			ingredients[i].Ingredient = &ingredient
+			if err := tx.Omit(clause.Associations).Create(&ingredients[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.NewInternalError(err)
+	}
+
+	return nil
+}
+
+func (r *ingredientRepository) ListIngredientsByProductID(productID string) ([]models.ProductIngredient, error) {
+	var ingredients []models.ProductIngredient
+	err := r.db.Preload("Ingredient").
+		Joins("JOIN active_ingredients ON active_ingredients.id = product_ingredients.ingredient_id").
+		Where("product_ingredients.product_id = ?", productID).
+		Order("active_ingredients.name asc").
+		Find(&ingredients).Error
+	if err != nil {
+		return nil, errors.NewInternalError(err)
+	}
+	return ingredients, nil
+}
+
+// FindEquivalentProducts returns the other products with the same dosage form and exactly the same
+// ingredients at the same strengths, cheapest first. Only products priced in the same currency compare.
+func (r *ingredientRepository) FindEquivalentProducts(productID uuid.UUID, dosageForm string, currency string, ingredients []models.ProductIngredient, limit int32) ([]models.Product, error) {
+	var products []models.Product
+
+	matches := make([]string, 0, len(ingredients))
+	args := make([]interface{}, 0, len(ingredients)*3+1)
+	for _, ingredient := range ingredients {
+		matches = append(matches, "(ingredient_id = ? AND strength = ? AND strength_unit = ?)")
+		args = append(args, ingredient.IngredientID, ingredient.Strength, ingredient.StrengthUnit)
+	}
+	args = append(args, len(ingredients))
+
+	// A product is equivalent when all of its ingredients match and it has as many as the source product
+	equivalentIDs := r.db.Model(&models.ProductIngredient{}).
+		Select("product_id").
+		Group("product_id").
+		Having("COUNT(*) = ?", len(ingredients)).
+		Having("SUM(CASE WHEN "+strings.Join(matches, " OR ")+" THEN 1 ELSE 0 END) = ?", args...)
+
+	query := r.db.Model(&models.Product{}).
+		Where("id <> ?", productID).
+		Where("status = ?", models.ProductStatusActive).
+		Where("NOT EXISTS (?)", productRecalled(r.db)).
+		Where("dosage_form = ?", dosageForm).
+		Where("currency = ?", currency).
+		Where("id IN (?)", equivalentIDs).
+		Order("price_minor asc")
+
+	if limit > 0 {
+		query = query.Limit(int(limit))
+	}
+
+	if err := query.Preload("Brand.Manufacturer").Preload("Images", orderedImages).Find(&products).Error; err != nil {
+		return nil, errors.NewInternalError(err)
+	}
+
+	return products, nil
+}
@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"github.com/PharmaKart/product-svc/internal/models"
+	"github.com/PharmaKart/product-svc/pkg/errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type InteractionRepository interface {
+	ImportInteractions(interactions []models.IngredientInteraction, replace bool) error
+	FindInteractions(ingredientIDs []uuid.UUID) ([]models.IngredientInteraction, error)
+}
+
+type interactionRepository struct {
+	db *gorm.DB
+}
+
+func NewInteractionRepository(db *gorm.DB) InteractionRepository {
+	return &interactionRepository{db}
+}
+
+// ImportInteractions upserts interactions whose ingredients are given by name, creating unknown ingredients.
+// With replace, interactions missing from the import are removed.
+func (r *interactionRepository) ImportInteractions(interactions []models.IngredientInteraction, replace bool) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if replace {
+			if err := tx.Where("1 = 1").Delete(&models.IngredientInteraction{}).Error; err != nil {
+				return err
+			}
+		}
+
+		for i := range interactions {
+			ingredientA := models.ActiveIngredient{Name: interactions[i].IngredientA.Name}
+			if err := tx.Where("name = ?", ingredientA.Name).FirstOrCreate(&ingredientA).Error; err != nil {
+				return err
+			}
+
+			ingredientB := models.ActiveIngredient{Name: interactions[i].IngredientB.Name}
+			if err := tx.Where("name = ?", ingredientB.Name).FirstOrCreate(&ingredientB).Error; err != nil {
+				return err
+			}
+
+			// Store each pair in a single order so it can only exist once
+			if ingredientB.ID.String() < ingredientA.ID.String() {
+				ingredientA, ingredientB = ingredientB, ingredientA
+			}
+			interactions[i].IngredientAID = ingredientA.ID
+			interactions[i].IngredientBID = ingredientB.ID
+
+			err := tx.Omit(clause.Associations).Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "ingredient_a_id"}, {Name: "ingredient_b_id"}},
+				DoUpdates: clause.AssignmentColumns([]string{"severity", "description", "updated_at"}),
+			}).Create(&interactions[i]).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.NewInternalError(err)
+	}
+
+	return nil
+}
+
+// FindInteractions returns the interactions between any two of the given ingredients
+func (r *interactionRepository) FindInteractions(ingredientIDs []uuid.UUID) ([]models.IngredientInteraction, error) {
+	var interactions []models.IngredientInteraction
+	if len(ingredientIDs) == 0 {
+		return interactions, nil
+	}
+
+	err := r.db.Preload("IngredientA").Preload("IngredientB").
+		Where("ingredient_a_id IN ? AND ingredient_b_id IN ?", ingredientIDs, ingredientIDs).
+		Find(&interactions).Error
+	if err != nil {
+		return nil, errors.NewInternalError(err)
+	}
+
+	return interactions, nil
+}
@@ -6,12 +6,14 @@ import (
 	"github.com/PharmaKart/product-svc/internal/models"
 	"github.com/PharmaKart/product-svc/pkg/errors"
 	"github.com/PharmaKart/product-svc/pkg/utils"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
 type InventoryLogRepository interface {
 	LogChange(log *models.InventoryLog) error
-	GetLogsByProductID(productID string, filter models.Filter, sortBy string, sortOrder string, page, limit int32) ([]models.InventoryLog, int32, error)
+	GetLogsByProductID(productID string, filter models.FilterExpression, sortBy string, sortOrder string, page, limit int32) ([]models.InventoryLog, int32, error)
+	GetOrderLogs(productID uuid.UUID, variantID *uuid.UUID, page, limit int32) ([]models.InventoryLog, int32, error)
 }
 
 type inventoryLogRepository struct {
@@ -29,48 +31,23 @@ func (r *inventoryLogRepository) LogChange(log *models.InventoryLog) error {
 	return nil
 }
 
-func (r *inventoryLogRepository) GetLogsByProductID(productID string, filter models.Filter, sortBy string, sortOrder string, page, limit int32) ([]models.InventoryLog, int32, error) {
+func (r *inventoryLogRepository) GetLogsByProductID(productID string, filter models.FilterExpression, sortBy string, sortOrder string, page, limit int32) ([]models.InventoryLog, int32, error) {
 	var logs []models.InventoryLog
 	var total int64
 
 	allowedColumns := utils.GetModelColumns(&models.InventoryLog{})
 
-	allowedOperators := map[string]string{
-		"eq":      "=",           // Equal to
-		"neq":     "!=",          // Not equal to
-		"gt":      ">",           // Greater than
-		"gte":     ">=",          // Greater than or equal to
-		"lt":      "<",           // Less than
-		"lte":     "<=",          // Less than or equal to
-		"like":    "LIKE",        // LIKE for pattern matching
-		"ilike":   "ILIKE",       // Case insensitive LIKE (for PostgreSQL)
-		"in":      "IN",          // IN for multiple values
-		"null":    "IS NULL",     // IS NULL check
-		"notnull": "IS NOT NULL", // IS NOT NULL check
-	}
-
 	query := r.db.Model(&models.InventoryLog{})
-	if filter != (models.Filter{}) {
-		if _, allowed := allowedColumns[filter.Column]; !allowed {
-			return nil, 0, errors.NewBadRequestError("invalid filter column: " + filter.Column)
-		}
-
-		op, allowed := allowedOperators[filter.Operator]
-		if !allowed {
-			return nil, 0, errors.NewBadRequestError("invalid filter operator: " + filter.Operator)
-		}
+	if productID != "" {
+		query = query.Where("product_id = ?", productID)
+	}
 
-		switch filter.Operator {
-		case "like", "ilike":
-			query = query.Where(filter.Column+" "+op+" ?", "%"+filter.Value+"%")
-		case "in":
-			values := strings.Split(filter.Value, ",")
-			query = query.Where(filter.Column+" "+op+" (?)", values)
-		case "null", "notnull":
-			query = query.Where(filter.Column + " " + op)
-		default:
-			query = query.Where(filter.Column+" "+op+" ?", filter.Value)
+	if !filter.IsEmpty() {
+		condition, args, err := filterSQL(filter, allowedColumns)
+		if err != nil {
+			return nil, 0, err
 		}
+		query = query.Where(condition, args...)
 	}
 
 	if sortBy != "" {
@@ -103,3 +80,36 @@ func (r *inventoryLogRepository) GetLogsByProductID(productID string, filter mod
 
 	return logs, int32(total), nil
 }
+
+// GetOrderLogs returns the orders placed for a product, or one of its variants, that were not cancelled, latest first
+func (r *inventoryLogRepository) GetOrderLogs(productID uuid.UUID, variantID *uuid.UUID, page, limit int32) ([]models.InventoryLog, int32, error) {
+	var logs []models.InventoryLog
+	var total int64
+
+	cancellations := r.db.Table("inventory_logs AS cancelled").Select("1").
+		Where("cancelled.change_type = ?", "order_cancelled").
+		Where("cancelled.order_id = inventory_logs.order_id AND cancelled.product_id = inventory_logs.product_id")
+
+	query := r.db.Model(&models.InventoryLog{}).
+		Where("product_id = ? AND change_type = ?", productID, "order_placed").
+		Where("(order_id IS NULL OR NOT EXISTS (?))", cancellations)
+
+	if variantID != nil {
+		query = query.Where("variant_id = ?", *variantID)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.NewInternalError(err)
+	}
+
+	if limit > 0 {
+		offset := max(int((page-1)*limit), 0)
+		query = query.Offset(offset).Limit(int(limit))
+	}
+
+	if err := query.Order("created_at desc").Find(&logs).Error; err != nil {
+		return nil, 0, errors.NewInternalError(err)
+	}
+
+	return logs, int32(total), nil
+}
@@ -0,0 +1,133 @@
+package repositories
+
+import (
+	"fmt"
+
+	"github.com/PharmaKart/product-svc/internal/models"
+	"github.com/PharmaKart/product-svc/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type ManufacturerRepository interface {
+	CreateManufacturer(manufacturer *models.Manufacturer) (string, error)
+	GetManufacturer(id string) (*models.Manufacturer, error)
+	GetManufacturerByName(name string) (*models.Manufacturer, error)
+	ListManufacturers(page, limit int32) ([]models.Manufacturer, int32, error)
+	UpdateManufacturer(manufacturer *models.Manufacturer) error
+	DeleteManufacturer(id string) error
+}
+
+type manufacturerRepository struct {
+	db *gorm.DB
+}
+
+func NewManufacturerRepository(db *gorm.DB) ManufacturerRepository {
+	return &manufacturerRepository{db}
+}
+
+func (r *manufacturerRepository) CreateManufacturer(manufacturer *models.Manufacturer) (string, error) {
+	existingManufacturer, err := r.GetManufacturerByName(manufacturer.Name)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); !ok || appErr.Type != errors.NotFoundError {
+			return "", err
+		}
+	}
+
+	if existingManufacturer != nil {
+		return "", errors.NewConflictError(fmt.Sprintf("Manufacturer with name '%s' already exists", manufacturer.Name))
+	}
+
+	if err := r.db.Create(manufacturer).Error; err != nil {
+		return "", errors.NewInternalError(err)
+	}
+	return manufacturer.ID.String(), nil
+}
+
+func (r *manufacturerRepository) GetManufacturer(id string) (*models.Manufacturer, error) {
+	var manufacturer models.Manufacturer
+	err := r.db.Where("id = ?", id).First(&manufacturer).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError(fmt.Sprintf("Manufacturer with ID '%s' not found", id))
+		}
+		return nil, errors.NewInternalError(err)
+	}
+	return &manufacturer, nil
+}
+
+func (r *manufacturerRepository) GetManufacturerByName(name string) (*models.Manufacturer, error) {
+	var manufacturer models.Manufacturer
+	err := r.db.Where("name = ?", name).First(&manufacturer).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError(fmt.Sprintf("Manufacturer with name '%s' not found", name))
+		}
+		return nil, errors.NewInternalError(err)
+	}
+	return &manufacturer, nil
+}
+
+func (r *manufacturerRepository) ListManufacturers(page, limit int32) ([]models.Manufacturer, int32, error) {
+	var manufacturers []models.Manufacturer
+	var total int64
+
+	query := r.db.Model(&models.Manufacturer{}).Order("name asc")
+
+	err := query.Count(&total).Error
+	if err != nil {
+		return nil, 0, errors.NewInternalError(err)
+	}
+
+	if limit > 0 {
+		offset := max(int((page-1)*limit), 0)
+		query = query.Offset(offset).Limit(int(limit))
+	}
+
+	err = query.Find(&manufacturers).Error
+	if err != nil {
+		return nil, 0, errors.NewInternalError(err)
+	}
+
+	return manufacturers, int32(total), nil
+}
+
+func (r *manufacturerRepository) UpdateManufacturer(manufacturer *models.Manufacturer) error {
+	existingManufacturer, err := r.GetManufacturerByName(manufacturer.Name)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); !ok || appErr.Type != errors.NotFoundError {
+			return err
+		}
+	}
+
+	if existingManufacturer != nil && existingManufacturer.ID != manufacturer.ID {
+		return errors.NewConflictError(fmt.Sprintf("Manufacturer with name '%s' already exists", manufacturer.Name))
+	}
+
+	if err := r.db.Save(manufacturer).Error; err != nil {
+		return errors.NewInternalError(err)
+	}
+
+	return nil
+}
+
+func (r *manufacturerRepository) DeleteManufacturer(id string) error {
+	_, err := r.GetManufacturer(id)
+	if err != nil {
+		return err
+	}
+
+	var brandCount int64
+	if err := r.db.Model(&models.Brand{}).Where("manufacturer_id = ?", id).Count(&brandCount).Error; err != nil {
+		return errors.NewInternalError(err)
+	}
+
+	if brandCount > 0 {
+		return errors.NewConflictError(fmt.Sprintf("Manufacturer with ID '%s' still has %d brand(s)", id, brandCount))
+	}
+
+	if err := r.db.Where("id = ?", id).Delete(&models.Manufacturer{}).Error; err != nil {
+		return errors.NewInternalError(err)
+	}
+
+	return nil
+}
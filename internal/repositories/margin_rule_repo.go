@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"fmt"
+
+	"github.com/PharmaKart/product-svc/internal/models"
+	"github.com/PharmaKart/product-svc/pkg/errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type MarginRuleRepository interface {
+	UpsertRule(rule *models.MarginRule) error
+	DeleteRule(id string) error
+	ListRules() ([]models.MarginRule, error)
+	ListRulesForProduct(productID uuid.UUID, brandID *uuid.UUID) ([]models.MarginRule, error)
+}
+
+type marginRuleRepository struct {
+	db *gorm.DB
+}
+
+func NewMarginRuleRepository(db *gorm.DB) MarginRuleRepository {
+	return &marginRuleRepository{db}
+}
+
+// UpsertRule creates the rule of a target, or replaces the existing one
+func (r *marginRuleRepository) UpsertRule(rule *models.MarginRule) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		query := tx.Where("target_type = ?", rule.TargetType)
+		if rule.TargetID != nil {
+			query = query.Where("target_id = ?", *rule.TargetID)
+		}
+
+		var existing models.MarginRule
+		err := query.First(&existing).Error
+		if err == gorm.ErrRecordNotFound {
+			return tx.Create(rule).Error
+		}
+		if err != nil {
+			return err
+		}
+
+		rule.ID = existing.ID
+		rule.CreatedAt = existing.CreatedAt
+		return tx.Model(&existing).Updates(map[string]interface{}{
+			"min_margin_basis_points": rule.MinMarginBasisPoints,
+			"enforcement":             rule.Enforcement,
+			"updated_at":              gorm.Expr("now()"),
+		}).Error
+	})
+	if err != nil {
+		return errors.NewInternalError(err)
+	}
+	return nil
+}
+
+func (r *marginRuleRepository) DeleteRule(id string) error {
+	result := r.db.Where("id = ?", id).Delete(&models.MarginRule{})
+	if result.Error != nil {
+		return errors.NewInternalError(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewNotFoundError(fmt.Sprintf("Margin rule with ID '%s' not found", id))
+	}
+
+	return nil
+}
+
+func (r *marginRuleRepository) ListRules() ([]models.MarginRule, error) {
+	var rules []models.MarginRule
+	if err := r.db.Order("target_type asc, created_at asc").Find(&rules).Error; err != nil {
+		return nil, errors.NewInternalError(err)
+	}
+	return rules, nil
+}
+
+// ListRulesForProduct returns the rules that may apply to a product: its own, its brand's and the rule for all products
+func (r *marginRuleRepository) ListRulesForProduct(productID uuid.UUID, brandID *uuid.UUID) ([]models.MarginRule, error) {
+	query := r.db.Where("target_type = ?", models.MarginRuleTargetAll).
+		Or("target_type = ? AND target_id = ?", models.MarginRuleTargetProduct, productID)
+	if brandID != nil {
+		query = query.Or("target_type = ? AND target_id = ?", models.MarginRuleTargetBrand, *brandID)
+	}
+
+	var rules []models.MarginRule
+	if err := query.Find(&rules).Error; err != nil {
+		return nil, errors.NewInternalError(err)
+	}
+	return rules, nil
+}
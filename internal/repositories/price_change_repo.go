@@ -0,0 +1,225 @@
+package repositories
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/PharmaKart/product-svc/internal/models"
+	"github.com/PharmaKart/product-svc/pkg/errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PriceChangeRepository interface {
+	CreateChange(change *models.PriceChange) (string, error)
+	GetChange(id string) (*models.PriceChange, error)
+	CancelChange(id string) error
+	ListChanges(productID string, variantID *uuid.UUID, includeScheduled bool, page, limit int32) ([]models.PriceChange, int32, error)
+	GetPriceAt(productID string, variantID *uuid.UUID, at time.Time) (*models.PriceChange, error)
+	ApplyDueChanges(now time.Time) (int64, error)
+	BackfillHistory() (int64, error)
+	WithTx(tx *gorm.DB) PriceChangeRepository
+}
+
+type priceChangeRepository struct {
+	db *gorm.DB
+}
+
+func NewPriceChangeRepository(db *gorm.DB) PriceChangeRepository {
+	return &priceChangeRepository{db}
+}
+
+// WithTx returns the repository running its queries in a transaction
+func (r *priceChangeRepository) WithTx(tx *gorm.DB) PriceChangeRepository {
+	return &priceChangeRepository{tx}
+}
+
+func (r *priceChangeRepository) CreateChange(change *models.PriceChange) (string, error) {
+	if err := r.db.Create(change).Error; err != nil {
+		return "", errors.NewInternalError(err)
+	}
+	return change.ID.String(), nil
+}
+
+func (r *priceChangeRepository) GetChange(id string) (*models.PriceChange, error) {
+	var change models.PriceChange
+	err := r.db.Where("id = ?", id).First(&change).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError(fmt.Sprintf("Price change with ID '%s' not found", id))
+		}
+		return nil, errors.NewInternalError(err)
+	}
+	return &change, nil
+}
+
+// CancelChange cancels a scheduled price change, applied changes are history and stay
+func (r *priceChangeRepository) CancelChange(id string) error {
+	result := r.db.Model(&models.PriceChange{}).
+		Where("id = ? AND status = ?", id, models.PriceChangeScheduled).
+		Update("status", models.PriceChangeCancelled)
+	if result.Error != nil {
+		return errors.NewInternalError(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		change, err := r.GetChange(id)
+		if err != nil {
+			return err
+		}
+		return errors.NewBadRequestError(fmt.Sprintf("Price change with status '%s' cannot be cancelled", change.Status))
+	}
+
+	return nil
+}
+
+// priceTarget limits price changes to a product's own price, or to one of its variants
+func priceTarget(query *gorm.DB, productID string, variantID *uuid.UUID) *gorm.DB {
+	query = query.Where("product_id = ?", productID)
+	if variantID != nil {
+		return query.Where("variant_id = ?", *variantID)
+	}
+	return query.Where("variant_id IS NULL")
+}
+
+// ListChanges returns the price changes of a product or variant, latest effective time first
+func (r *priceChangeRepository) ListChanges(productID string, variantID *uuid.UUID, includeScheduled bool, page, limit int32) ([]models.PriceChange, int32, error) {
+	var changes []models.PriceChange
+	var total int64
+
+	query := priceTarget(r.db.Model(&models.PriceChange{}), productID, variantID)
+	if !includeScheduled {
+		query = query.Where("status = ?", models.PriceChangeApplied)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.NewInternalError(err)
+	}
+
+	if limit > 0 {
+		offset := max(int((page-1)*limit), 0)
+		query = query.Offset(offset).Limit(int(limit))
+	}
+
+	if err := query.Order("effective_at desc, created_at desc").Find(&changes).Error; err != nil {
+		return nil, 0, errors.NewInternalError(err)
+	}
+
+	return changes, int32(total), nil
+}
+
+// GetPriceAt returns the applied price change that was in effect at a point in time
+func (r *priceChangeRepository) GetPriceAt(productID string, variantID *uuid.UUID, at time.Time) (*models.PriceChange, error) {
+	var change models.PriceChange
+	err := priceTarget(r.db, productID, variantID).
+		Where("status = ? AND effective_at <= ?", models.PriceChangeApplied, at).
+		Order("effective_at desc, created_at desc").
+		First(&change).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError(fmt.Sprintf("No price recorded for product with ID '%s' at %s", productID, at.Format(time.RFC3339)))
+		}
+		return nil, errors.NewInternalError(err)
+	}
+	return &change, nil
+}
+
+// ApplyDueChanges applies the scheduled price changes whose effective time has passed, in effective order
+// so the latest due change of a product or variant wins
+func (r *priceChangeRepository) ApplyDueChanges(now time.Time) (int64, error) {
+	var changes []models.PriceChange
+	err := r.db.Where("status = ? AND effective_at <= ?", models.PriceChangeScheduled, now).
+		Order("effective_at asc, created_at asc").
+		Find(&changes).Error
+	if err != nil {
+		return 0, errors.NewInternalError(err)
+	}
+
+	var applied int64
+	for _, change := range changes {
+		changed := false
+		err := r.db.Transaction(func(tx *gorm.DB) error {
+			// Claim the change first, a change cancelled since it was loaded is skipped
+			result := tx.Model(&models.PriceChange{}).
+				Where("id = ? AND status = ?", change.ID, models.PriceChangeScheduled).
+				Update("status", models.PriceChangeApplied)
+			if result.Error != nil {
+				return result.Error
+			}
+
+			if result.RowsAffected == 0 {
+				return nil
+			}
+
+			// Prices are in the currency of the product, a change scheduled in a currency it no longer has fails
+			var product models.Product
+			if err := tx.Unscoped().Where("id = ?", change.ProductID).First(&product).Error; err != nil {
+				return err
+			}
+
+			if product.Currency != change.Currency {
+				return tx.Model(&models.PriceChange{}).Where("id = ?", change.ID).Update("status", models.PriceChangeFailed).Error
+			}
+
+			price := change.Price()
+			updates := map[string]interface{}{"price_minor": price.Amount, "price": price.Decimal()}
+
+			changed = true
+			if change.VariantID != nil {
+				return tx.Model(&models.ProductVariant{}).Where("id = ?", *change.VariantID).Updates(updates).Error
+			}
+
+			// Deleted products get the new price too, should they be restored
+			if err := tx.Unscoped().Model(&models.Product{}).Where("id = ?", change.ProductID).Updates(updates).Error; err != nil {
+				return err
+			}
+
+			previous := models.NewProductSnapshot(&product)
+			product.PriceMinor = price.Amount
+			product.Price = price.Decimal()
+
+			version, err := models.NewProductVersion(product.ID, models.ProductActionUpdate, change.Actor, &previous, models.NewProductSnapshot(&product))
+			if err != nil {
+				return err
+			}
+			return createProductVersion(tx, version)
+		})
+		if err != nil {
+			return applied, errors.NewInternalError(err)
+		}
+
+		if changed {
+			applied++
+		}
+	}
+
+	return applied, nil
+}
+
+// BackfillHistory records the current price of products and variants priced before price history was
+// kept. Their price is only known to apply since they were last updated.
+func (r *priceChangeRepository) BackfillHistory() (int64, error) {
+	result := r.db.Exec(`INSERT INTO price_changes (id, product_id, price_minor, currency, effective_at, status, actor, created_at)
+		SELECT uuid_generate_v4(), products.id, products.price_minor, products.currency, products.updated_at, ?, 'system', now()
+		FROM products
+		WHERE products.price_minor > 0
+		AND NOT EXISTS (SELECT 1 FROM price_changes WHERE price_changes.product_id = products.id AND price_changes.variant_id IS NULL)`,
+		models.PriceChangeApplied)
+	if result.Error != nil {
+		return 0, errors.NewInternalError(result.Error)
+	}
+	backfilled := result.RowsAffected
+
+	result = r.db.Exec(`INSERT INTO price_changes (id, product_id, variant_id, price_minor, currency, effective_at, status, actor, created_at)
+		SELECT uuid_generate_v4(), product_variants.product_id, product_variants.id, product_variants.price_minor, products.currency, product_variants.updated_at, ?, 'system', now()
+		FROM product_variants
+		JOIN products ON products.id = product_variants.product_id
+		WHERE product_variants.price_minor > 0
+		AND NOT EXISTS (SELECT 1 FROM price_changes WHERE price_changes.variant_id = product_variants.id)`,
+		models.PriceChangeApplied)
+	if result.Error != nil {
+		return backfilled, errors.NewInternalError(result.Error)
+	}
+
+	return backfilled + result.RowsAffected, nil
+}
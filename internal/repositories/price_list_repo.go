@@ -0,0 +1,191 @@
+package repositories
+
+import (
+	"fmt"
+
+	"github.com/PharmaKart/product-svc/internal/models"
+	"github.com/PharmaKart/product-svc/pkg/errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PriceListRepository interface {
+	CreatePriceList(priceList *models.PriceList) (string, error)
+	GetPriceList(id string) (*models.PriceList, error)
+	GetDefaultPriceList(currency string, channel string) (*models.PriceList, error)
+	ListPriceLists(currency string, channel string) ([]models.PriceList, error)
+	DeletePriceList(id string) error
+	UpsertItems(items []models.PriceListItem) error
+	DeleteItem(priceListID string, productID string) error
+	ListItems(priceListID string, page, limit int32) ([]models.PriceListItem, int32, error)
+	ListItemsByProductIDs(priceListID uuid.UUID, productIDs []uuid.UUID) ([]models.PriceListItem, error)
+	IsListed(productID uuid.UUID) (bool, error)
+}
+
+type priceListRepository struct {
+	db *gorm.DB
+}
+
+func NewPriceListRepository(db *gorm.DB) PriceListRepository {
+	return &priceListRepository{db}
+}
+
+func (r *priceListRepository) CreatePriceList(priceList *models.PriceList) (string, error) {
+	var count int64
+	if err := r.db.Model(&models.PriceList{}).Where("name = ?", priceList.Name).Count(&count).Error; err != nil {
+		return "", errors.NewInternalError(err)
+	}
+
+	if count > 0 {
+		return "", errors.NewConflictError(fmt.Sprintf("Price list with name '%s' already exists", priceList.Name))
+	}
+
+	// Only one list is the default of a currency and channel
+	if priceList.IsDefault {
+		if _, err := r.GetDefaultPriceList(priceList.Currency, priceList.Channel); err == nil {
+			return "", errors.NewConflictError(fmt.Sprintf("A default %s price list for channel '%s' already exists", priceList.Currency, priceList.Channel))
+		} else if appErr, ok := errors.IsAppError(err); !ok || appErr.Type != errors.NotFoundError {
+			return "", err
+		}
+	}
+
+	if err := r.db.Create(priceList).Error; err != nil {
+		return "", errors.NewInternalError(err)
+	}
+	return priceList.ID.String(), nil
+}
+
+func (r *priceListRepository) GetPriceList(id string) (*models.PriceList, error) {
+	var priceList models.PriceList
+	err := r.db.Where("id = ?", id).First(&priceList).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError(fmt.Sprintf("Price list with ID '%s' not found", id))
+		}
+		return nil, errors.NewInternalError(err)
+	}
+	return &priceList, nil
+}
+
+func (r *priceListRepository) GetDefaultPriceList(currency string, channel string) (*models.PriceList, error) {
+	var priceList models.PriceList
+	err := r.db.Where("currency = ? AND channel = ? AND is_default = ?", currency, channel, true).First(&priceList).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError(fmt.Sprintf("No default %s price list for channel '%s'", currency, channel))
+		}
+		return nil, errors.NewInternalError(err)
+	}
+	return &priceList, nil
+}
+
+func (r *priceListRepository) ListPriceLists(currency string, channel string) ([]models.PriceList, error) {
+	var priceLists []models.PriceList
+	query := r.db.Order("name asc")
+	if currency != "" {
+		query = query.Where("currency = ?", currency)
+	}
+
+	if channel != "" {
+		query = query.Where("channel = ?", channel)
+	}
+
+	if err := query.Find(&priceLists).Error; err != nil {
+		return nil, errors.NewInternalError(err)
+	}
+	return priceLists, nil
+}
+
+// DeletePriceList deletes a price list together with its prices
+func (r *priceListRepository) DeletePriceList(id string) error {
+	if _, err := r.GetPriceList(id); err != nil {
+		return err
+	}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("price_list_id = ?", id).Delete(&models.PriceListItem{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&models.PriceList{}).Error
+	})
+	if err != nil {
+		return errors.NewInternalError(err)
+	}
+	return nil
+}
+
+// UpsertItems sets the prices of products in price lists, replacing their existing prices
+func (r *priceListRepository) UpsertItems(items []models.PriceListItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	err := r.db.Omit(clause.Associations).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "price_list_id"}, {Name: "product_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"price_minor", "updated_at"}),
+	}).Create(&items).Error
+	if err != nil {
+		return errors.NewInternalError(err)
+	}
+	return nil
+}
+
+func (r *priceListRepository) DeleteItem(priceListID string, productID string) error {
+	result := r.db.Where("price_list_id = ? AND product_id = ?", priceListID, productID).Delete(&models.PriceListItem{})
+	if result.Error != nil {
+		return errors.NewInternalError(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewNotFoundError(fmt.Sprintf("Price of product with ID '%s' not found in price list with ID '%s'", productID, priceListID))
+	}
+
+	return nil
+}
+
+// ListItems returns the prices of a price list with their products, by product name
+func (r *priceListRepository) ListItems(priceListID string, page, limit int32) ([]models.PriceListItem, int32, error) {
+	var items []models.PriceListItem
+	var total int64
+
+	query := r.db.Model(&models.PriceListItem{}).
+		Joins("JOIN products ON products.id = price_list_items.product_id AND products.deleted_at IS NULL").
+		Where("price_list_items.price_list_id = ?", priceListID)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.NewInternalError(err)
+	}
+
+	if limit > 0 {
+		offset := max(int((page-1)*limit), 0)
+		query = query.Offset(offset).Limit(int(limit))
+	}
+
+	if err := query.Preload("Product").Order("products.name asc").Find(&items).Error; err != nil {
+		return nil, 0, errors.NewInternalError(err)
+	}
+
+	return items, int32(total), nil
+}
+
+func (r *priceListRepository) ListItemsByProductIDs(priceListID uuid.UUID, productIDs []uuid.UUID) ([]models.PriceListItem, error) {
+	var items []models.PriceListItem
+	if len(productIDs) == 0 {
+		return items, nil
+	}
+
+	if err := r.db.Where("price_list_id = ? AND product_id IN ?", priceListID, productIDs).Find(&items).Error; err != nil {
+		return nil, errors.NewInternalError(err)
+	}
+	return items, nil
+}
+
+// IsListed reports whether the product has a price in any price list
+func (r *priceListRepository) IsListed(productID uuid.UUID) (bool, error) {
+	var count int64
+	if err := r.db.Model(&models.PriceListItem{}).Where("product_id = ?", productID).Count(&count).Error; err != nil {
+		return false, errors.NewInternalError(err)
+	}
+	return count > 0, nil
+}
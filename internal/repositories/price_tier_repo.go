@@ -0,0 +1,56 @@
+package repositories
+
+import (
+	"github.com/PharmaKart/product-svc/internal/models"
+	"github.com/PharmaKart/product-svc/pkg/errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PriceTierRepository interface {
+	ReplaceTiers(productID uuid.UUID, tiers []models.PriceTier) error
+	ListTiers(productIDs []uuid.UUID) ([]models.PriceTier, error)
+}
+
+type priceTierRepository struct {
+	db *gorm.DB
+}
+
+func NewPriceTierRepository(db *gorm.DB) PriceTierRepository {
+	return &priceTierRepository{db}
+}
+
+// ReplaceTiers swaps the price tiers of a product, no tiers leaves only the base price
+func (r *priceTierRepository) ReplaceTiers(productID uuid.UUID, tiers []models.PriceTier) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("product_id = ?", productID).Delete(&models.PriceTier{}).Error; err != nil {
+			return err
+		}
+
+		for i := range tiers {
+			tiers[i].ProductID = productID
+			if err := tx.Create(&tiers[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.NewInternalError(err)
+	}
+
+	return nil
+}
+
+// ListTiers returns the price tiers of the products by minimum quantity
+func (r *priceTierRepository) ListTiers(productIDs []uuid.UUID) ([]models.PriceTier, error) {
+	var tiers []models.PriceTier
+	if len(productIDs) == 0 {
+		return tiers, nil
+	}
+
+	if err := r.db.Where("product_id IN ?", productIDs).Order("min_quantity asc").Find(&tiers).Error; err != nil {
+		return nil, errors.NewInternalError(err)
+	}
+	return tiers, nil
+}
@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"fmt"
+
+	"github.com/PharmaKart/product-svc/internal/models"
+	"github.com/PharmaKart/product-svc/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type ProductIdentifierRepository interface {
+	CreateIdentifier(identifier *models.ProductIdentifier) (string, error)
+	GetIdentifier(identifierType string, value string) (*models.ProductIdentifier, error)
+	ListIdentifiersByProductID(productID string) ([]models.ProductIdentifier, error)
+	DeleteIdentifier(id string) error
+}
+
+type productIdentifierRepository struct {
+	db *gorm.DB
+}
+
+func NewProductIdentifierRepository(db *gorm.DB) ProductIdentifierRepository {
+	return &productIdentifierRepository{db}
+}
+
+func (r *productIdentifierRepository) CreateIdentifier(identifier *models.ProductIdentifier) (string, error) {
+	existingIdentifier, err := r.GetIdentifier(identifier.Type, identifier.Value)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); !ok || appErr.Type != errors.NotFoundError {
+			return "", err
+		}
+	}
+
+	if existingIdentifier != nil {
+		return "", errors.NewConflictError(fmt.Sprintf("Identifier %s '%s' is already assigned to product '%s'", identifier.Type, identifier.Value, existingIdentifier.ProductID))
+	}
+
+	if err := r.db.Create(identifier).Error; err != nil {
+		return "", errors.NewInternalError(err)
+	}
+	return identifier.ID.String(), nil
+}
+
+func (r *productIdentifierRepository) GetIdentifier(identifierType string, value string) (*models.ProductIdentifier, error) {
+	var identifier models.ProductIdentifier
+	err := r.db.Where("type = ? AND value = ?", identifierType, value).First(&identifier).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError(fmt.Sprintf("Identifier %s '%s' not found", identifierType, value))
+		}
+		return nil, errors.NewInternalError(err)
+	}
+	return &identifier, nil
+}
+
+func (r *productIdentifierRepository) ListIdentifiersByProductID(productID string) ([]models.ProductIdentifier, error) {
+	var identifiers []models.ProductIdentifier
+	if err := r.db.Where("product_id = ?", productID).Order("type asc, value asc").Find(&identifiers).Error; err != nil {
+		return nil, errors.NewInternalError(err)
+	}
+	return identifiers, nil
+}
+
+func (r *productIdentifierRepository) DeleteIdentifier(id string) error {
+	result := r.db.Where("id = ?", id).Delete(&models.ProductIdentifier{})
+	if result.Error != nil {
+		return errors.NewInternalError(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewNotFoundError(fmt.Sprintf("Identifier with ID '%s' not found", id))
+	}
+
+	return nil
+}
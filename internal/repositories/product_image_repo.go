@@ -0,0 +1,225 @@
+package repositories
+
+import (
+	"fmt"
+
+	"github.com/PharmaKart/product-svc/internal/models"
+	"github.com/PharmaKart/product-svc/pkg/errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ProductImageRepository interface {
+	CreateImage(image *models.ProductImage) (string, error)
+	GetImage(id string) (*models.ProductImage, error)
+	ListImagesByProductID(productID string) ([]models.ProductImage, error)
+	ReorderImages(productID uuid.UUID, imageIDs []uuid.UUID, primaryImageID *uuid.UUID) error
+	DeleteImage(id string) error
+	SetPrimaryImageURL(productID uuid.UUID, url string) error
+	BackfillFromImageURL() (int64, error)
+	WithTx(tx *gorm.DB) ProductImageRepository
+}
+
+type productImageRepository struct {
+	db *gorm.DB
+}
+
+func NewProductImageRepository(db *gorm.DB) ProductImageRepository {
+	return &productImageRepository{db}
+}
+
+// WithTx returns the repository running its queries in a transaction
+func (r *productImageRepository) WithTx(tx *gorm.DB) ProductImageRepository {
+	return &productImageRepository{tx}
+}
+
+// CreateImage appends an image to the product's images. The first image of a product is always primary.
+func (r *productImageRepository) CreateImage(image *models.ProductImage) (string, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var stats struct {
+			Count        int64
+			MaxSortOrder int
+		}
+		err := tx.Model(&models.ProductImage{}).
+			Select("COUNT(*) AS count, COALESCE(MAX(sort_order), -1) AS max_sort_order").
+			Where("product_id = ?", image.ProductID).
+			Scan(&stats).Error
+		if err != nil {
+			return err
+		}
+
+		image.SortOrder = stats.MaxSortOrder + 1
+		if stats.Count == 0 {
+			image.IsPrimary = true
+		}
+
+		if image.IsPrimary {
+			if err := tx.Model(&models.ProductImage{}).Where("product_id = ?", image.ProductID).Update("is_primary", false).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Create(image).Error
+	})
+	if err != nil {
+		return "", errors.NewInternalError(err)
+	}
+	return image.ID.String(), nil
+}
+
+func (r *productImageRepository) GetImage(id string) (*models.ProductImage, error) {
+	var image models.ProductImage
+	err := r.db.Where("id = ?", id).First(&image).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError(fmt.Sprintf("Image with ID '%s' not found", id))
+		}
+		return nil, errors.NewInternalError(err)
+	}
+	return &image, nil
+}
+
+func (r *productImageRepository) ListImagesByProductID(productID string) ([]models.ProductImage, error) {
+	var images []models.ProductImage
+	if err := r.db.Where("product_id = ?", productID).Order("sort_order asc").Find(&images).Error; err != nil {
+		return nil, errors.NewInternalError(err)
+	}
+	return images, nil
+}
+
+// ReorderImages sets the sort order of the product's images to the order of imageIDs, which must list
+// every image of the product, and optionally moves the primary flag
+func (r *productImageRepository) ReorderImages(productID uuid.UUID, imageIDs []uuid.UUID, primaryImageID *uuid.UUID) error {
+	images, err := r.ListImagesByProductID(productID.String())
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[uuid.UUID]bool, len(images))
+	for _, image := range images {
+		existing[image.ID] = true
+	}
+
+	if len(imageIDs) != len(images) {
+		return errors.NewValidationError("imageIds", "Image IDs must list every image of the product exactly once")
+	}
+
+	for _, id := range imageIDs {
+		if !existing[id] {
+			return errors.NewValidationError("imageIds", fmt.Sprintf("Image with ID '%s' does not belong to the product", id))
+		}
+		delete(existing, id)
+	}
+
+	if primaryImageID != nil {
+		var found bool
+		for _, id := range imageIDs {
+			found = found || id == *primaryImageID
+		}
+		if !found {
+			return errors.NewValidationError("primaryImageId", "Primary image does not belong to the product")
+		}
+	}
+
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		for i, id := range imageIDs {
+			updates := map[string]interface{}{"sort_order": i}
+			if primaryImageID != nil {
+				updates["is_primary"] = id == *primaryImageID
+			}
+
+			if err := tx.Model(&models.ProductImage{}).Where("id = ?", id).Updates(updates).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.NewInternalError(err)
+	}
+
+	return nil
+}
+
+// DeleteImage removes an image, promoting the next image of the product when the primary one is removed
+func (r *productImageRepository) DeleteImage(id string) error {
+	image, err := r.GetImage(id)
+	if err != nil {
+		return err
+	}
+
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("id = ?", id).Delete(&models.ProductImage{}).Error; err != nil {
+			return err
+		}
+
+		if !image.IsPrimary {
+			return nil
+		}
+
+		var next models.ProductImage
+		err := tx.Where("product_id = ?", image.ProductID).Order("sort_order asc").First(&next).Error
+		if err == gorm.ErrRecordNotFound {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(&next).Update("is_primary", true).Error
+	})
+	if err != nil {
+		return errors.NewInternalError(err)
+	}
+
+	return nil
+}
+
+// SetPrimaryImageURL points the primary image of a product to a URL, for clients that still set the single
+// image URL. A product without images gets it as its first image.
+func (r *productImageRepository) SetPrimaryImageURL(productID uuid.UUID, url string) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.ProductImage{}).Where("product_id = ? AND is_primary = ?", productID, true).Update("url", url)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected > 0 {
+			return nil
+		}
+
+		return tx.Create(&models.ProductImage{ProductID: productID, URL: url, IsPrimary: true}).Error
+	})
+	if err != nil {
+		return errors.NewInternalError(err)
+	}
+	return nil
+}
+
+// orderedImages preloads product images in their display order
+func orderedImages(db *gorm.DB) *gorm.DB {
+	return db.Order("sort_order asc")
+}
+
+// BackfillFromImageURL copies the single image URL products had before they had ordered images to a primary
+// image, for products without images, and then clears the URL. Each URL is only copied once, so images removed
+// later are not brought back on the next start.
+func (r *productImageRepository) BackfillFromImageURL() (int64, error) {
+	var backfilled int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Exec(`INSERT INTO product_images (id, product_id, url, sort_order, is_primary, created_at)
+			SELECT uuid_generate_v4(), products.id, products.image_url, 0, true, now() FROM products
+			WHERE products.image_url IS NOT NULL AND products.image_url <> ''
+			AND NOT EXISTS (SELECT 1 FROM product_images WHERE product_images.product_id = products.id)`)
+		if result.Error != nil {
+			return result.Error
+		}
+		backfilled = result.RowsAffected
+
+		return tx.Exec("UPDATE products SET image_url = NULL WHERE image_url IS NOT NULL").Error
+	})
+	if err != nil {
+		return 0, errors.NewInternalError(err)
+	}
+	return backfilled, nil
+}
@@ -0,0 +1,107 @@
+package repositories
+
+import (
+	"fmt"
+
+	"github.com/PharmaKart/product-svc/internal/models"
+	"github.com/PharmaKart/product-svc/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ProductRelationRepository interface {
+	CreateRelation(relation *models.ProductRelation) (string, error)
+	GetRelation(id string) (*models.ProductRelation, error)
+	ListRelationsByProductID(productID string, types []string) ([]models.ProductRelation, error)
+	DeleteRelation(id string) error
+}
+
+type productRelationRepository struct {
+	db *gorm.DB
+}
+
+func NewProductRelationRepository(db *gorm.DB) ProductRelationRepository {
+	return &productRelationRepository{db}
+}
+
+func (r *productRelationRepository) CreateRelation(relation *models.ProductRelation) (string, error) {
+	var count int64
+	err := r.db.Model(&models.ProductRelation{}).
+		Where("product_id = ? AND related_product_id = ? AND type = ?", relation.ProductID, relation.RelatedProductID, relation.Type).
+		Count(&count).Error
+	if err != nil {
+		return "", errors.NewInternalError(err)
+	}
+
+	if count > 0 {
+		return "", errors.NewConflictError(fmt.Sprintf("Product is already related as '%s'", relation.Type))
+	}
+
+	// A discontinued product points customers to a single replacement
+	if relation.Type == models.RelationReplacement {
+		err := r.db.Model(&models.ProductRelation{}).
+			Where("product_id = ? AND type = ?", relation.ProductID, models.RelationReplacement).
+			Count(&count).Error
+		if err != nil {
+			return "", errors.NewInternalError(err)
+		}
+
+		if count > 0 {
+			return "", errors.NewConflictError("Product already has a replacement")
+		}
+	}
+
+	if err := r.db.Omit(clause.Associations).Create(relation).Error; err != nil {
+		return "", errors.NewInternalError(err)
+	}
+	return relation.ID.String(), nil
+}
+
+func (r *productRelationRepository) GetRelation(id string) (*models.ProductRelation, error) {
+	var relation models.ProductRelation
+	err := r.db.Where("id = ?", id).First(&relation).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError(fmt.Sprintf("Relation with ID '%s' not found", id))
+		}
+		return nil, errors.NewInternalError(err)
+	}
+	return &relation, nil
+}
+
+// ListRelationsByProductID returns the relations of a product to active products, optionally of some types only,
+// in their display order
+func (r *productRelationRepository) ListRelationsByProductID(productID string, types []string) ([]models.ProductRelation, error) {
+	var relations []models.ProductRelation
+
+	query := r.db.Joins("JOIN products ON products.id = product_relations.related_product_id").
+		Where("product_relations.product_id = ?", productID).
+		Where("products.status = ? AND products.deleted_at IS NULL", models.ProductStatusActive).
+		Where("NOT EXISTS (?)", productRecalled(r.db))
+
+	if len(types) > 0 {
+		query = query.Where("product_relations.type IN ?", types)
+	}
+
+	err := query.Preload("RelatedProduct.Brand.Manufacturer").
+		Preload("RelatedProduct.Images", orderedImages).
+		Order("product_relations.type asc, product_relations.sort_order asc, product_relations.created_at asc").
+		Find(&relations).Error
+	if err != nil {
+		return nil, errors.NewInternalError(err)
+	}
+	return relations, nil
+}
+
+func (r *productRelationRepository) DeleteRelation(id string) error {
+	result := r.db.Where("id = ?", id).Delete(&models.ProductRelation{})
+	if result.Error != nil {
+		return errors.NewInternalError(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewNotFoundError(fmt.Sprintf("Relation with ID '%s' not found", id))
+	}
+
+	return nil
+}
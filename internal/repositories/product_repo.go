@@ -2,23 +2,39 @@ package repositories
 
 import (
 	"fmt"
+	"math"
 	"strings"
+	"time"
 
 	"github.com/PharmaKart/product-svc/internal/models"
 	"github.com/PharmaKart/product-svc/pkg/errors"
 	"github.com/PharmaKart/product-svc/pkg/utils"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ProductRepository interface {
 	CreateProduct(product *models.Product) (string, error)
 	GetProduct(id string) (*models.Product, error)
+	GetProductIncludingDeleted(id string) (*models.Product, error)
 	GetProductByName(name string) (*models.Product, error)
-	ListProducts(search string, filter models.Filter, sortBy string, sortOrder string, page, limit int32) ([]models.Product, int32, error)
+	GetProductBySlug(slug string) (*models.Product, error)
+	GetSlugRedirect(slug string) (*models.ProductSlug, error)
+	SetSlug(productID uuid.UUID, name string) (string, error)
+	ListProductIDsWithoutSlug(limit int) ([]uuid.UUID, error)
+	BackfillPrescriptionClasses() (int64, error)
+	BackfillPriceMinorUnits() (int64, error)
+	ListProducts(search string, filter models.FilterExpression, options models.ProductListOptions, sortBy string, sortOrder string, page, limit int32) ([]models.Product, int32, error)
+	GetBrandFacets(search string, filter models.FilterExpression, options models.ProductListOptions) ([]models.BrandFacet, error)
 	UpdateProduct(product *models.Product) error
 	DeleteProduct(id string) error
+	RestoreProduct(id string) error
+	PurgeDeletedProducts(deletedBefore time.Time) (int64, error)
 	UpdateStock(id uuid.UUID, quantity int) error
+	UpdateStatus(id string, status string) error
+	Transaction(fn func(tx *gorm.DB) error) error
+	WithTx(tx *gorm.DB) ProductRepository
 }
 
 type productRepository struct {
@@ -29,6 +45,22 @@ func NewProductRepository(db *gorm.DB) ProductRepository {
 	return &productRepository{db}
 }
 
+// Transaction runs a function in a database transaction, repositories made with WithTx(tx) take part in it
+func (r *productRepository) Transaction(fn func(tx *gorm.DB) error) error {
+	if err := r.db.Transaction(fn); err != nil {
+		if _, ok := errors.IsAppError(err); ok {
+			return err
+		}
+		return errors.NewInternalError(err)
+	}
+	return nil
+}
+
+// WithTx returns the repository running its queries in a transaction
+func (r *productRepository) WithTx(tx *gorm.DB) ProductRepository {
+	return &productRepository{tx}
+}
+
 func (r *productRepository) CreateProduct(product *models.Product) (string, error) {
 	existingProduct, err := r.GetProductByName(product.Name)
 	if err != nil {
@@ -49,7 +81,19 @@ func (r *productRepository) CreateProduct(product *models.Product) (string, erro
 
 func (r *productRepository) GetProduct(id string) (*models.Product, error) {
 	var product models.Product
-	err := r.db.Where("id = ?", id).First(&product).Error
+	err := r.db.Preload("Brand.Manufacturer").Preload("Images", orderedImages).Preload("Recalls", activeRecalls).Where("id = ?", id).First(&product).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError(fmt.Sprintf("Product with ID '%s' not found", id))
+		}
+		return nil, errors.NewInternalError(err)
+	}
+	return &product, nil
+}
+
+func (r *productRepository) GetProductIncludingDeleted(id string) (*models.Product, error) {
+	var product models.Product
+	err := r.db.Unscoped().Preload("Brand.Manufacturer").Preload("Images", orderedImages).Preload("Recalls", activeRecalls).Where("id = ?", id).First(&product).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, errors.NewNotFoundError(fmt.Sprintf("Product with ID '%s' not found", id))
@@ -71,53 +115,142 @@ func (r *productRepository) GetProductByName(name string) (*models.Product, erro
 	return &product, nil
 }
 
-func (r *productRepository) ListProducts(search string, filter models.Filter, sortBy string, sortOrder string, page, limit int32) ([]models.Product, int32, error) {
-	var products []models.Product
-	var total int64
+func (r *productRepository) GetProductBySlug(slug string) (*models.Product, error) {
+	var product models.Product
+	err := r.db.Where("slug = ?", slug).First(&product).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError(fmt.Sprintf("Product with slug '%s' not found", slug))
+		}
+		return nil, errors.NewInternalError(err)
+	}
+	return &product, nil
+}
+
+// GetSlugRedirect finds the product that used to have a slug
+func (r *productRepository) GetSlugRedirect(slug string) (*models.ProductSlug, error) {
+	var redirect models.ProductSlug
+	err := r.db.Where("slug = ?", slug).First(&redirect).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError(fmt.Sprintf("Product with slug '%s' not found", slug))
+		}
+		return nil, errors.NewInternalError(err)
+	}
+	return &redirect, nil
+}
+
+// SetSlug gives a product the first free slug derived from the name, adding a numeric suffix when taken.
+// The previous slug is kept as a redirect, and a product may take back one of its own previous slugs.
+func (r *productRepository) SetSlug(productID uuid.UUID, name string) (string, error) {
+	base := utils.Slugify(name)
+
+	var slug string
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var product models.Product
+		if err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", productID).First(&product).Error; err != nil {
+			return err
+		}
+
+		for i := 1; ; i++ {
+			slug = base
+			if i > 1 {
+				slug = fmt.Sprintf("%s-%d", base, i)
+			}
+
+			var taken int64
+			err := tx.Unscoped().Model(&models.Product{}).Where("slug = ? AND id <> ?", slug, productID).Count(&taken).Error
+			if err != nil {
+				return err
+			}
+
+			if taken == 0 {
+				err = tx.Model(&models.ProductSlug{}).Where("slug = ? AND product_id <> ?", slug, productID).Count(&taken).Error
+				if err != nil {
+					return err
+				}
+			}
+
+			if taken == 0 {
+				break
+			}
+		}
+
+		if product.Slug != nil && *product.Slug == slug {
+			return nil
+		}
+
+		if err := tx.Where("product_id = ? AND slug = ?", productID, slug).Delete(&models.ProductSlug{}).Error; err != nil {
+			return err
+		}
 
-	allowedColumns := utils.GetModelColumns(&models.Product{})
+		if product.Slug != nil {
+			if err := tx.Create(&models.ProductSlug{ProductID: productID, Slug: *product.Slug}).Error; err != nil {
+				return err
+			}
+		}
 
-	allowedOperators := map[string]string{
-		"eq":      "=",           // Equal to
-		"neq":     "!=",          // Not equal to
-		"gt":      ">",           // Greater than
-		"gte":     ">=",          // Greater than or equal to
-		"lt":      "<",           // Less than
-		"lte":     "<=",          // Less than or equal to
-		"like":    "LIKE",        // LIKE for pattern matching
-		"ilike":   "ILIKE",       // Case insensitive LIKE (for PostgreSQL)
-		"in":      "IN",          // IN for multiple values
-		"null":    "IS NULL",     // IS NULL check
-		"notnull": "IS NOT NULL", // IS NOT NULL check
+		return tx.Unscoped().Model(&models.Product{}).Where("id = ?", productID).Update("slug", slug).Error
+	})
+	if err != nil {
+		return "", errors.NewInternalError(err)
 	}
 
-	query := r.db.Model(&models.Product{})
+	return slug, nil
+}
 
-	if search != "" {
-		query = query.Where("name ILIKE ? OR description ILIKE ?", "%"+search+"%", "%"+search+"%")
+// ListProductIDsWithoutSlug returns products created before slugs were introduced
+func (r *productRepository) ListProductIDsWithoutSlug(limit int) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	if err := r.db.Unscoped().Model(&models.Product{}).Where("slug IS NULL").Limit(limit).Pluck("id", &ids).Error; err != nil {
+		return nil, errors.NewInternalError(err)
 	}
+	return ids, nil
+}
 
-	if filter != (models.Filter{}) {
-		if _, allowed := allowedColumns[filter.Column]; !allowed {
-			return nil, 0, errors.NewBadRequestError("invalid filter column: " + filter.Column)
-		}
+// BackfillPrescriptionClasses classifies products that only have the prescription flag, from before
+// prescription classes were introduced, as prescription products
+func (r *productRepository) BackfillPrescriptionClasses() (int64, error) {
+	result := r.db.Unscoped().Model(&models.Product{}).
+		Where("requires_prescription = ? AND prescription_class = ?", true, models.PrescriptionClassOTC).
+		Update("prescription_class", models.PrescriptionClassPrescription)
+	if result.Error != nil {
+		return 0, errors.NewInternalError(result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// BackfillPriceMinorUnits converts the decimal prices of products and variants from before prices were
+// stored in minor units, variants are priced in the currency of their product
+func (r *productRepository) BackfillPriceMinorUnits() (int64, error) {
+	var updated int64
+	for currency, exponent := range models.CurrencyExponents {
+		scale := math.Pow10(exponent)
 
-		op, allowed := allowedOperators[filter.Operator]
-		if !allowed {
-			return nil, 0, errors.NewBadRequestError("invalid filter operator: " + filter.Operator)
+		result := r.db.Exec("UPDATE products SET price_minor = ROUND(price * ?) WHERE price_minor = 0 AND price > 0 AND currency = ?", scale, currency)
+		if result.Error != nil {
+			return updated, errors.NewInternalError(result.Error)
 		}
+		updated += result.RowsAffected
 
-		switch filter.Operator {
-		case "like", "ilike":
-			query = query.Where(filter.Column+" "+op+" ?", "%"+filter.Value+"%")
-		case "in":
-			values := strings.Split(filter.Value, ",")
-			query = query.Where(filter.Column+" "+op+" (?)", values)
-		case "null", "notnull":
-			query = query.Where(filter.Column + " " + op)
-		default:
-			query = query.Where(filter.Column+" "+op+" ?", filter.Value)
+		result = r.db.Exec("UPDATE product_variants SET price_minor = ROUND(product_variants.price * ?) FROM products WHERE products.id = product_variants.product_id AND product_variants.price_minor = 0 AND product_variants.price > 0 AND products.currency = ?", scale, currency)
+		if result.Error != nil {
+			return updated, errors.NewInternalError(result.Error)
 		}
+		updated += result.RowsAffected
+	}
+	return updated, nil
+}
+
+func (r *productRepository) ListProducts(search string, filter models.FilterExpression, options models.ProductListOptions, sortBy string, sortOrder string, page, limit int32) ([]models.Product, int32, error) {
+	var products []models.Product
+	var total int64
+
+	allowedColumns := productColumns(options.Visibility)
+
+	query, err := r.filterProducts(search, filter, options)
+	if err != nil {
+		return nil, 0, err
 	}
 
 	if sortBy != "" {
@@ -133,7 +266,7 @@ func (r *productRepository) ListProducts(search string, filter models.Filter, so
 		query = query.Order(sortBy + " " + sortOrder)
 	}
 
-	err := query.Count(&total).Error
+	err = query.Count(&total).Error
 	if err != nil {
 		return nil, 0, errors.NewInternalError(err)
 	}
@@ -143,7 +276,7 @@ func (r *productRepository) ListProducts(search string, filter models.Filter, so
 		query = query.Offset(offset).Limit(int(limit))
 	}
 
-	err = query.Find(&products).Error
+	err = query.Preload("Brand.Manufacturer").Preload("Images", orderedImages).Preload("Recalls", activeRecalls).Find(&products).Error
 	if err != nil {
 		return nil, 0, errors.NewInternalError(err)
 	}
@@ -151,6 +284,136 @@ func (r *productRepository) ListProducts(search string, filter models.Filter, so
 	return products, int32(total), nil
 }
 
+func (r *productRepository) GetBrandFacets(search string, filter models.FilterExpression, options models.ProductListOptions) ([]models.BrandFacet, error) {
+	query, err := r.filterProducts(search, filter, options)
+	if err != nil {
+		return nil, err
+	}
+
+	var counts []struct {
+		BrandID uuid.UUID
+		Count   int64
+	}
+	err = query.Select("brand_id, COUNT(*) AS count").Where("brand_id IS NOT NULL").Group("brand_id").Order("count desc").Scan(&counts).Error
+	if err != nil {
+		return nil, errors.NewInternalError(err)
+	}
+
+	if len(counts) == 0 {
+		return nil, nil
+	}
+
+	brandIDs := make([]uuid.UUID, 0, len(counts))
+	for _, count := range counts {
+		brandIDs = append(brandIDs, count.BrandID)
+	}
+
+	var brands []models.Brand
+	if err := r.db.Where("id IN ?", brandIDs).Find(&brands).Error; err != nil {
+		return nil, errors.NewInternalError(err)
+	}
+
+	brandNames := make(map[uuid.UUID]string, len(brands))
+	for _, brand := range brands {
+		brandNames[brand.ID] = brand.Name
+	}
+
+	facets := make([]models.BrandFacet, 0, len(counts))
+	for _, count := range counts {
+		facets = append(facets, models.BrandFacet{
+			BrandID:   count.BrandID,
+			BrandName: brandNames[count.BrandID],
+			Count:     count.Count,
+		})
+	}
+
+	return facets, nil
+}
+
+// productColumns returns the product columns a caller may filter and sort by, the cost price is hidden
+// from callers that cannot see it
+func productColumns(visibility models.ProductVisibility) map[string]bool {
+	columns := utils.GetModelColumns(&models.Product{})
+	if !visibility.IncludeCost {
+		delete(columns, "cost_minor")
+	}
+	return columns
+}
+
+// filterProducts builds the product query shared by listings and their facets
+func (r *productRepository) filterProducts(search string, filter models.FilterExpression, options models.ProductListOptions) (*gorm.DB, error) {
+	allowedColumns := productColumns(options.Visibility)
+
+	query := r.db.Model(&models.Product{})
+	if options.Visibility.IncludeDeleted {
+		query = query.Unscoped()
+	}
+
+	if search != "" {
+		pattern := "%" + search + "%"
+		if len(options.Locales) > 0 {
+			// Match the translations in the requested locales as well as the product's own content
+			translations := r.db.Model(&models.ProductTranslation{}).Select("1").
+				Where("product_translations.product_id = products.id AND product_translations.locale IN ?", options.Locales).
+				Where("(product_translations.name ILIKE ? OR product_translations.description ILIKE ?)", pattern, pattern)
+			query = query.Where("products.name ILIKE ? OR products.description ILIKE ? OR EXISTS (?)", pattern, pattern, translations)
+		} else {
+			query = query.Where("name ILIKE ? OR description ILIKE ?", pattern, pattern)
+		}
+	}
+
+	if !filter.IsEmpty() {
+		condition, args, err := filterSQL(filter, allowedColumns)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where(condition, args...)
+	}
+
+	// Only active products are listed unless other statuses are requested
+	statuses := options.Statuses
+	if len(statuses) == 0 {
+		statuses = []string{models.ProductStatusActive}
+	}
+	// A product with an active recall of the whole product is recalled whatever its stored status
+	query = query.Where("(CASE WHEN EXISTS (?) THEN ? ELSE products.status END) IN ?", productRecalled(r.db), models.ProductStatusRecalled, statuses)
+
+	if options.BrandID != "" {
+		query = query.Where("brand_id = ?", options.BrandID)
+	}
+
+	if options.ManufacturerID != "" {
+		query = query.Where("brand_id IN (?)", r.db.Model(&models.Brand{}).Select("id").Where("manufacturer_id = ?", options.ManufacturerID))
+	}
+
+	if options.DosageForm != "" {
+		query = query.Where("dosage_form = ?", strings.ToLower(options.DosageForm))
+	}
+
+	if options.Route != "" {
+		query = query.Where("route = ?", strings.ToLower(options.Route))
+	}
+
+	if options.StorageCondition != "" {
+		query = query.Where("storage_condition = ?", strings.ToLower(options.StorageCondition))
+	}
+
+	if options.Region != "" && !options.IncludeUnavailable {
+		condition, args := notSoldInRegion(r.db, options.Region)
+		query = query.Where("NOT "+condition, args...)
+	}
+
+	if len(options.PrescriptionClasses) > 0 {
+		query = query.Where("prescription_class IN ?", options.PrescriptionClasses)
+	}
+
+	if options.RequiresColdChain != nil {
+		query = query.Where("requires_cold_chain = ?", *options.RequiresColdChain)
+	}
+
+	return query, nil
+}
+
 func (r *productRepository) UpdateProduct(product *models.Product) error {
 	_, err := r.GetProduct(product.ID.String())
 	if err != nil {
@@ -164,7 +427,7 @@ func (r *productRepository) UpdateProduct(product *models.Product) error {
 		}
 	}
 
-	if err := r.db.Save(product).Error; err != nil {
+	if err := r.db.Omit(clause.Associations).Save(product).Error; err != nil {
 		return errors.NewInternalError(err)
 	}
 
@@ -177,6 +440,7 @@ func (r *productRepository) DeleteProduct(id string) error {
 		return err
 	}
 
+	// Products are soft deleted so their history stays intact until they are purged
 	if err := r.db.Where("id = ?", id).Delete(&models.Product{}).Error; err != nil {
 		return errors.NewInternalError(err)
 	}
@@ -184,6 +448,98 @@ func (r *productRepository) DeleteProduct(id string) error {
 	return nil
 }
 
+func (r *productRepository) RestoreProduct(id string) error {
+	result := r.db.Unscoped().Model(&models.Product{}).Where("id = ? AND deleted_at IS NOT NULL", id).Update("deleted_at", nil)
+	if result.Error != nil {
+		return errors.NewInternalError(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewNotFoundError(fmt.Sprintf("Deleted product with ID '%s' not found", id))
+	}
+
+	return nil
+}
+
+// PurgeDeletedProducts permanently removes the products soft deleted before the given time, with everything that refers
+// to them except their history. The inventory log, the versions and the price history of a purged product are kept.
+func (r *productRepository) PurgeDeletedProducts(deletedBefore time.Time) (int64, error) {
+	var purged int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var ids []uuid.UUID
+		// Recalled products are kept, the recall refers to them. Components of bundles that stay are kept too, so
+		// the contents and stock of those bundles do not change.
+		err := tx.Unscoped().Model(&models.Product{}).
+			Where("deleted_at < ?", deletedBefore).
+			Where("NOT EXISTS (SELECT 1 FROM recalls WHERE recalls.product_id = products.id)").
+			Where(`NOT EXISTS (SELECT 1 FROM bundle_components JOIN products bundles ON bundles.id = bundle_components.bundle_id
+				WHERE bundle_components.component_id = products.id
+				AND (bundles.deleted_at IS NULL OR bundles.deleted_at >= ? OR EXISTS (SELECT 1 FROM recalls WHERE recalls.product_id = bundles.id)))`, deletedBefore).
+			Pluck("id", &ids).Error
+		if err != nil {
+			return err
+		}
+
+		if len(ids) == 0 {
+			return nil
+		}
+
+		if err := tx.Where("bundle_id IN ?", ids).Delete(&models.BundleComponent{}).Error; err != nil {
+			return err
+		}
+
+		dependents := []interface{}{
+			&models.ProductVariant{},
+			&models.ProductIdentifier{},
+			&models.ProductIngredient{},
+			&models.ProductImage{},
+			&models.ProductSlug{},
+			&models.ProductTranslation{},
+			&models.ProductRelation{},
+			&models.ProductRegionRule{},
+			&models.PriceListItem{},
+			&models.PriceTier{},
+		}
+		for _, dependent := range dependents {
+			if err := tx.Where("product_id IN ?", ids).Delete(dependent).Error; err != nil {
+				return err
+			}
+		}
+
+		// Applied prices stay as the price history, prices that were still to come never apply
+		err = tx.Model(&models.PriceChange{}).
+			Where("product_id IN ? AND status = ?", ids, models.PriceChangeScheduled).
+			Update("status", models.PriceChangeCancelled).Error
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Where("related_product_id IN ?", ids).Delete(&models.ProductRelation{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("target_type = ? AND target_id IN ?", models.PromotionTargetProduct, ids).Delete(&models.PromotionTarget{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("target_type = ? AND target_id IN ?", models.MarginRuleTargetProduct, ids).Delete(&models.MarginRule{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Unscoped().Where("id IN ?", ids).Delete(&models.Product{})
+		if result.Error != nil {
+			return result.Error
+		}
+		purged = result.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return 0, errors.NewInternalError(err)
+	}
+
+	return purged, nil
+}
+
 func (r *productRepository) UpdateStock(id uuid.UUID, quantity int) error {
 	_, err := r.GetProduct(id.String())
 	if err != nil {
@@ -201,3 +557,16 @@ func (r *productRepository) UpdateStock(id uuid.UUID, quantity int) error {
 
 	return nil
 }
+
+func (r *productRepository) UpdateStatus(id string, status string) error {
+	result := r.db.Model(&models.Product{}).Where("id = ?", id).Update("status", status)
+	if result.Error != nil {
+		return errors.NewInternalError(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewNotFoundError(fmt.Sprintf("Product with ID '%s' not found", id))
+	}
+
+	return nil
+}
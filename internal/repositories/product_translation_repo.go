@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"fmt"
+
+	"github.com/PharmaKart/product-svc/internal/models"
+	"github.com/PharmaKart/product-svc/pkg/errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ProductTranslationRepository interface {
+	UpsertTranslation(translation *models.ProductTranslation) error
+	DeleteTranslation(productID string, locale string) error
+	ListTranslationsByProductID(productID string) ([]models.ProductTranslation, error)
+	ListTranslations(productIDs []uuid.UUID, locales []string) ([]models.ProductTranslation, error)
+}
+
+type productTranslationRepository struct {
+	db *gorm.DB
+}
+
+func NewProductTranslationRepository(db *gorm.DB) ProductTranslationRepository {
+	return &productTranslationRepository{db}
+}
+
+// UpsertTranslation creates the translation of a product in a locale, or replaces the existing one
+func (r *productTranslationRepository) UpsertTranslation(translation *models.ProductTranslation) error {
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "product_id"}, {Name: "locale"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name", "description", "updated_at"}),
+	}).Create(translation).Error
+	if err != nil {
+		return errors.NewInternalError(err)
+	}
+	return nil
+}
+
+func (r *productTranslationRepository) DeleteTranslation(productID string, locale string) error {
+	result := r.db.Where("product_id = ? AND locale = ?", productID, locale).Delete(&models.ProductTranslation{})
+	if result.Error != nil {
+		return errors.NewInternalError(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewNotFoundError(fmt.Sprintf("Translation '%s' of product with ID '%s' not found", locale, productID))
+	}
+
+	return nil
+}
+
+func (r *productTranslationRepository) ListTranslationsByProductID(productID string) ([]models.ProductTranslation, error) {
+	var translations []models.ProductTranslation
+	if err := r.db.Where("product_id = ?", productID).Order("locale asc").Find(&translations).Error; err != nil {
+		return nil, errors.NewInternalError(err)
+	}
+	return translations, nil
+}
+
+// ListTranslations returns the translations of the products in any of the locales
+func (r *productTranslationRepository) ListTranslations(productIDs []uuid.UUID, locales []string) ([]models.ProductTranslation, error) {
+	var translations []models.ProductTranslation
+	if len(productIDs) == 0 || len(locales) == 0 {
+		return translations, nil
+	}
+
+	if err := r.db.Where("product_id IN ? AND locale IN ?", productIDs, locales).Find(&translations).Error; err != nil {
+		return nil, errors.NewInternalError(err)
+	}
+	return translations, nil
+}
@@ -0,0 +1,136 @@
+package repositories
+
+import (
+	"fmt"
+
+	"github.com/PharmaKart/product-svc/internal/models"
+	"github.com/PharmaKart/product-svc/pkg/errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ProductVariantRepository interface {
+	CreateVariant(variant *models.ProductVariant) (string, error)
+	GetVariant(id string) (*models.ProductVariant, error)
+	GetVariantBySKU(sku string) (*models.ProductVariant, error)
+	ListVariantsByProductID(productID string) ([]models.ProductVariant, error)
+	UpdateVariant(variant *models.ProductVariant) error
+	DeleteVariant(id string) error
+	UpdateStock(id uuid.UUID, quantity int) error
+	WithTx(tx *gorm.DB) ProductVariantRepository
+}
+
+type productVariantRepository struct {
+	db *gorm.DB
+}
+
+func NewProductVariantRepository(db *gorm.DB) ProductVariantRepository {
+	return &productVariantRepository{db}
+}
+
+// WithTx returns the repository running its queries in a transaction
+func (r *productVariantRepository) WithTx(tx *gorm.DB) ProductVariantRepository {
+	return &productVariantRepository{tx}
+}
+
+func (r *productVariantRepository) CreateVariant(variant *models.ProductVariant) (string, error) {
+	existingVariant, err := r.GetVariantBySKU(variant.SKU)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); !ok || appErr.Type != errors.NotFoundError {
+			return "", err
+		}
+	}
+
+	if existingVariant != nil {
+		return "", errors.NewConflictError(fmt.Sprintf("Variant with SKU '%s' already exists", variant.SKU))
+	}
+
+	if err := r.db.Create(variant).Error; err != nil {
+		return "", errors.NewInternalError(err)
+	}
+	return variant.ID.String(), nil
+}
+
+func (r *productVariantRepository) GetVariant(id string) (*models.ProductVariant, error) {
+	var variant models.ProductVariant
+	err := r.db.Where("id = ?", id).First(&variant).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError(fmt.Sprintf("Variant with ID '%s' not found", id))
+		}
+		return nil, errors.NewInternalError(err)
+	}
+	return &variant, nil
+}
+
+func (r *productVariantRepository) GetVariantBySKU(sku string) (*models.ProductVariant, error) {
+	var variant models.ProductVariant
+	err := r.db.Where("sku = ?", sku).First(&variant).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError(fmt.Sprintf("Variant with SKU '%s' not found", sku))
+		}
+		return nil, errors.NewInternalError(err)
+	}
+	return &variant, nil
+}
+
+func (r *productVariantRepository) ListVariantsByProductID(productID string) ([]models.ProductVariant, error) {
+	var variants []models.ProductVariant
+	if err := r.db.Where("product_id = ?", productID).Order("name asc").Find(&variants).Error; err != nil {
+		return nil, errors.NewInternalError(err)
+	}
+	return variants, nil
+}
+
+func (r *productVariantRepository) UpdateVariant(variant *models.ProductVariant) error {
+	existingVariant, err := r.GetVariantBySKU(variant.SKU)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); !ok || appErr.Type != errors.NotFoundError {
+			return err
+		}
+	}
+
+	if existingVariant != nil && existingVariant.ID != variant.ID {
+		return errors.NewConflictError(fmt.Sprintf("Variant with SKU '%s' already exists", variant.SKU))
+	}
+
+	if err := r.db.Save(variant).Error; err != nil {
+		return errors.NewInternalError(err)
+	}
+
+	return nil
+}
+
+func (r *productVariantRepository) DeleteVariant(id string) error {
+	_, err := r.GetVariant(id)
+	if err != nil {
+		return err
+	}
+
+	// Identifiers of the variant go with it, so lookups do not find a deleted variant
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("variant_id = ?", id).Delete(&models.ProductIdentifier{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&models.ProductVariant{}).Error
+	})
+	if err != nil {
+		return errors.NewInternalError(err)
+	}
+
+	return nil
+}
+
+func (r *productVariantRepository) UpdateStock(id uuid.UUID, quantity int) error {
+	result := r.db.Model(&models.ProductVariant{}).Where("id = ?", id).Update("stock", gorm.Expr("stock + ?", quantity))
+	if result.Error != nil {
+		return errors.NewInternalError(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewNotFoundError(fmt.Sprintf("Variant with ID '%s' not found", id))
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package repositories
+
+import (
+	"fmt"
+
+	"github.com/PharmaKart/product-svc/internal/models"
+	"github.com/PharmaKart/product-svc/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ProductVersionRepository interface {
+	CreateVersion(version *models.ProductVersion) error
+	ListVersions(productID string, page, limit int32) ([]models.ProductVersion, int32, error)
+	GetVersion(productID string, version int32) (*models.ProductVersion, error)
+	WithTx(tx *gorm.DB) ProductVersionRepository
+}
+
+type productVersionRepository struct {
+	db *gorm.DB
+}
+
+func NewProductVersionRepository(db *gorm.DB) ProductVersionRepository {
+	return &productVersionRepository{db}
+}
+
+// WithTx returns the repository running its queries in a transaction
+func (r *productVersionRepository) WithTx(tx *gorm.DB) ProductVersionRepository {
+	return &productVersionRepository{tx}
+}
+
+// CreateVersion stores a version with its field changes as the next version of the product
+func (r *productVersionRepository) CreateVersion(version *models.ProductVersion) error {
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		return createProductVersion(tx, version)
+	}); err != nil {
+		return errors.NewInternalError(err)
+	}
+	return nil
+}
+
+// createProductVersion numbers and stores a version in a transaction
+func createProductVersion(tx *gorm.DB, version *models.ProductVersion) error {
+	// Lock the product row so concurrent changes get distinct version numbers
+	if err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Where("id = ?", version.ProductID).First(&models.Product{}).Error; err != nil {
+		return err
+	}
+
+	var latest int
+	if err := tx.Model(&models.ProductVersion{}).Select("COALESCE(MAX(version), 0)").Where("product_id = ?", version.ProductID).Scan(&latest).Error; err != nil {
+		return err
+	}
+
+	version.Version = latest + 1
+	return tx.Create(version).Error
+}
+
+// ListVersions returns the versions of a product with their field changes, latest first
+func (r *productVersionRepository) ListVersions(productID string, page, limit int32) ([]models.ProductVersion, int32, error) {
+	var versions []models.ProductVersion
+	var total int64
+
+	query := r.db.Model(&models.ProductVersion{}).Where("product_id = ?", productID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.NewInternalError(err)
+	}
+
+	if limit > 0 {
+		offset := max(int((page-1)*limit), 0)
+		query = query.Offset(offset).Limit(int(limit))
+	}
+
+	if err := query.Preload("Changes").Order("version desc").Find(&versions).Error; err != nil {
+		return nil, 0, errors.NewInternalError(err)
+	}
+
+	return versions, int32(total), nil
+}
+
+func (r *productVersionRepository) GetVersion(productID string, version int32) (*models.ProductVersion, error) {
+	var productVersion models.ProductVersion
+	err := r.db.Preload("Changes").Where("product_id = ? AND version = ?", productID, version).First(&productVersion).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError(fmt.Sprintf("Version %d of product with ID '%s' not found", version, productID))
+		}
+		return nil, errors.NewInternalError(err)
+	}
+	return &productVersion, nil
+}
@@ -0,0 +1,117 @@
+package repositories
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/PharmaKart/product-svc/internal/models"
+	"github.com/PharmaKart/product-svc/pkg/errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PromotionRepository interface {
+	CreatePromotion(promotion *models.Promotion) (string, error)
+	GetPromotion(id string) (*models.Promotion, error)
+	ListPromotions(activeAt *time.Time, page, limit int32) ([]models.Promotion, int32, error)
+	DeletePromotion(id string) error
+	ListActivePromotions(productIDs []uuid.UUID, brandIDs []uuid.UUID, at time.Time) ([]models.Promotion, error)
+}
+
+type promotionRepository struct {
+	db *gorm.DB
+}
+
+func NewPromotionRepository(db *gorm.DB) PromotionRepository {
+	return &promotionRepository{db}
+}
+
+// CreatePromotion creates a promotion together with its targets
+func (r *promotionRepository) CreatePromotion(promotion *models.Promotion) (string, error) {
+	if err := r.db.Create(promotion).Error; err != nil {
+		return "", errors.NewInternalError(err)
+	}
+	return promotion.ID.String(), nil
+}
+
+func (r *promotionRepository) GetPromotion(id string) (*models.Promotion, error) {
+	var promotion models.Promotion
+	err := r.db.Preload("Targets").Where("id = ?", id).First(&promotion).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError(fmt.Sprintf("Promotion with ID '%s' not found", id))
+		}
+		return nil, errors.NewInternalError(err)
+	}
+	return &promotion, nil
+}
+
+// ListPromotions returns the promotions, or only those active at a point in time, by start date
+func (r *promotionRepository) ListPromotions(activeAt *time.Time, page, limit int32) ([]models.Promotion, int32, error) {
+	var promotions []models.Promotion
+	var total int64
+
+	query := r.db.Model(&models.Promotion{})
+	if activeAt != nil {
+		query = query.Where("starts_at <= ? AND ends_at > ?", *activeAt, *activeAt)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.NewInternalError(err)
+	}
+
+	if limit > 0 {
+		offset := max(int((page-1)*limit), 0)
+		query = query.Offset(offset).Limit(int(limit))
+	}
+
+	if err := query.Preload("Targets").Order("starts_at desc").Find(&promotions).Error; err != nil {
+		return nil, 0, errors.NewInternalError(err)
+	}
+
+	return promotions, int32(total), nil
+}
+
+// DeletePromotion deletes a promotion together with its targets
+func (r *promotionRepository) DeletePromotion(id string) error {
+	if _, err := r.GetPromotion(id); err != nil {
+		return err
+	}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("promotion_id = ?", id).Delete(&models.PromotionTarget{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&models.Promotion{}).Error
+	})
+	if err != nil {
+		return errors.NewInternalError(err)
+	}
+	return nil
+}
+
+// ListActivePromotions returns the promotions active at a point in time that target any of the products or brands
+func (r *promotionRepository) ListActivePromotions(productIDs []uuid.UUID, brandIDs []uuid.UUID, at time.Time) ([]models.Promotion, error) {
+	var promotions []models.Promotion
+	if len(productIDs) == 0 {
+		return promotions, nil
+	}
+
+	matchesTarget := r.db.Where("promotion_targets.target_type = ? AND promotion_targets.target_id IN ?", models.PromotionTargetProduct, productIDs)
+	if len(brandIDs) > 0 {
+		matchesTarget = matchesTarget.Or("promotion_targets.target_type = ? AND promotion_targets.target_id IN ?", models.PromotionTargetBrand, brandIDs)
+	}
+
+	targets := r.db.Model(&models.PromotionTarget{}).Select("1").
+		Where("promotion_targets.promotion_id = promotions.id").
+		Where(matchesTarget)
+
+	err := r.db.Preload("Targets").
+		Where("starts_at <= ? AND ends_at > ?", at, at).
+		Where("EXISTS (?)", targets).
+		Find(&promotions).Error
+	if err != nil {
+		return nil, errors.NewInternalError(err)
+	}
+	return promotions, nil
+}
@@ -0,0 +1,83 @@
+package repositories
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/PharmaKart/product-svc/internal/models"
+	"github.com/PharmaKart/product-svc/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type RecallRepository interface {
+	CreateRecall(recall *models.Recall) (string, error)
+	GetRecall(id string) (*models.Recall, error)
+	ListRecallsByProductID(productID string, activeOnly bool) ([]models.Recall, error)
+	ResolveRecall(id string, resolvedAt time.Time) error
+}
+
+type recallRepository struct {
+	db *gorm.DB
+}
+
+func NewRecallRepository(db *gorm.DB) RecallRepository {
+	return &recallRepository{db}
+}
+
+func (r *recallRepository) CreateRecall(recall *models.Recall) (string, error) {
+	if err := r.db.Create(recall).Error; err != nil {
+		return "", errors.NewInternalError(err)
+	}
+	return recall.ID.String(), nil
+}
+
+func (r *recallRepository) GetRecall(id string) (*models.Recall, error) {
+	var recall models.Recall
+	err := r.db.Where("id = ?", id).First(&recall).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError(fmt.Sprintf("Recall with ID '%s' not found", id))
+		}
+		return nil, errors.NewInternalError(err)
+	}
+	return &recall, nil
+}
+
+// ListRecallsByProductID returns the recalls of a product, latest first
+func (r *recallRepository) ListRecallsByProductID(productID string, activeOnly bool) ([]models.Recall, error) {
+	var recalls []models.Recall
+	query := r.db.Where("product_id = ?", productID)
+	if activeOnly {
+		query = query.Scopes(activeRecalls)
+	}
+
+	if err := query.Order("effective_date desc").Find(&recalls).Error; err != nil {
+		return nil, errors.NewInternalError(err)
+	}
+	return recalls, nil
+}
+
+func (r *recallRepository) ResolveRecall(id string, resolvedAt time.Time) error {
+	result := r.db.Model(&models.Recall{}).Where("id = ? AND resolved_at IS NULL", id).Update("resolved_at", resolvedAt)
+	if result.Error != nil {
+		return errors.NewInternalError(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewNotFoundError(fmt.Sprintf("Unresolved recall with ID '%s' not found", id))
+	}
+
+	return nil
+}
+
+// activeRecalls limits a recall query to the recalls in effect now
+func activeRecalls(db *gorm.DB) *gorm.DB {
+	return db.Where("recalls.resolved_at IS NULL AND recalls.effective_date <= ?", time.Now())
+}
+
+// productRecalled is a subquery matching products with an active recall of the whole product
+func productRecalled(db *gorm.DB) *gorm.DB {
+	return db.Model(&models.Recall{}).Select("1").
+		Where("recalls.product_id = products.id AND recalls.variant_id IS NULL").
+		Scopes(activeRecalls)
+}
@@ -0,0 +1,90 @@
+package repositories
+
+import (
+	"fmt"
+
+	"github.com/PharmaKart/product-svc/internal/models"
+	"github.com/PharmaKart/product-svc/pkg/errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RegionRuleRepository interface {
+	UpsertRule(rule *models.ProductRegionRule) error
+	DeleteRule(productID string, region string) error
+	ListRulesByProductID(productID string) ([]models.ProductRegionRule, error)
+	ListRules(productIDs []uuid.UUID, regions []string) ([]models.ProductRegionRule, error)
+}
+
+type regionRuleRepository struct {
+	db *gorm.DB
+}
+
+func NewRegionRuleRepository(db *gorm.DB) RegionRuleRepository {
+	return &regionRuleRepository{db}
+}
+
+// UpsertRule creates the rule of a product for a region, or replaces the existing one
+func (r *regionRuleRepository) UpsertRule(rule *models.ProductRegionRule) error {
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "product_id"}, {Name: "region"}},
+		DoUpdates: clause.AssignmentColumns([]string{"rule", "max_quantity", "updated_at"}),
+	}).Create(rule).Error
+	if err != nil {
+		return errors.NewInternalError(err)
+	}
+	return nil
+}
+
+func (r *regionRuleRepository) DeleteRule(productID string, region string) error {
+	result := r.db.Where("product_id = ? AND region = ?", productID, region).Delete(&models.ProductRegionRule{})
+	if result.Error != nil {
+		return errors.NewInternalError(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewNotFoundError(fmt.Sprintf("Rule for region '%s' of product with ID '%s' not found", region, productID))
+	}
+
+	return nil
+}
+
+func (r *regionRuleRepository) ListRulesByProductID(productID string) ([]models.ProductRegionRule, error) {
+	var rules []models.ProductRegionRule
+	if err := r.db.Where("product_id = ?", productID).Order("region asc").Find(&rules).Error; err != nil {
+		return nil, errors.NewInternalError(err)
+	}
+	return rules, nil
+}
+
+// ListRules returns the rules of the products for any of the regions
+func (r *regionRuleRepository) ListRules(productIDs []uuid.UUID, regions []string) ([]models.ProductRegionRule, error) {
+	var rules []models.ProductRegionRule
+	if len(productIDs) == 0 || len(regions) == 0 {
+		return rules, nil
+	}
+
+	if err := r.db.Where("product_id IN ? AND region IN ?", productIDs, regions).Find(&rules).Error; err != nil {
+		return nil, errors.NewInternalError(err)
+	}
+	return rules, nil
+}
+
+// notSoldInRegion is a condition matching products whose most specific rule for the region is not_sold
+func notSoldInRegion(db *gorm.DB, region string) (string, []interface{}) {
+	regions := models.RegionFallbacks(region)
+
+	exact := db.Model(&models.ProductRegionRule{}).Select("1").
+		Where("product_region_rules.product_id = products.id AND product_region_rules.region = ?", regions[0])
+	exactNotSold := db.Model(&models.ProductRegionRule{}).Select("1").
+		Where("product_region_rules.product_id = products.id AND product_region_rules.region = ? AND product_region_rules.rule = ?", regions[0], models.RegionRuleNotSold)
+
+	if len(regions) == 1 {
+		return "EXISTS (?)", []interface{}{exactNotSold}
+	}
+
+	countryNotSold := db.Model(&models.ProductRegionRule{}).Select("1").
+		Where("product_region_rules.product_id = products.id AND product_region_rules.region = ? AND product_region_rules.rule = ?", regions[1], models.RegionRuleNotSold)
+	return "(EXISTS (?) OR (NOT EXISTS (?) AND EXISTS (?)))", []interface{}{exactNotSold, exact, countryNotSold}
+}
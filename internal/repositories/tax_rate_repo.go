@@ -0,0 +1,63 @@
+package repositories
+
+import (
+	"fmt"
+
+	"github.com/PharmaKart/product-svc/internal/models"
+	"github.com/PharmaKart/product-svc/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TaxRateRepository interface {
+	UpsertRate(rate *models.TaxRate) error
+	DeleteRate(region string, taxCategory string) error
+	ListRates(regions []string) ([]models.TaxRate, error)
+}
+
+type taxRateRepository struct {
+	db *gorm.DB
+}
+
+func NewTaxRateRepository(db *gorm.DB) TaxRateRepository {
+	return &taxRateRepository{db}
+}
+
+// UpsertRate creates the rate of a tax category for a region, or replaces the existing one
+func (r *taxRateRepository) UpsertRate(rate *models.TaxRate) error {
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "region"}, {Name: "tax_category"}},
+		DoUpdates: clause.AssignmentColumns([]string{"rate_milli_percent", "updated_at"}),
+	}).Create(rate).Error
+	if err != nil {
+		return errors.NewInternalError(err)
+	}
+	return nil
+}
+
+func (r *taxRateRepository) DeleteRate(region string, taxCategory string) error {
+	result := r.db.Where("region = ? AND tax_category = ?", region, taxCategory).Delete(&models.TaxRate{})
+	if result.Error != nil {
+		return errors.NewInternalError(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewNotFoundError(fmt.Sprintf("Tax rate '%s' for region '%s' not found", taxCategory, region))
+	}
+
+	return nil
+}
+
+// ListRates returns the rates of any of the regions, or every rate when no region is given
+func (r *taxRateRepository) ListRates(regions []string) ([]models.TaxRate, error) {
+	query := r.db.Model(&models.TaxRate{})
+	if len(regions) > 0 {
+		query = query.Where("region IN ?", regions)
+	}
+
+	var rates []models.TaxRate
+	if err := query.Order("region asc, tax_category asc").Find(&rates).Error; err != nil {
+		return nil, errors.NewInternalError(err)
+	}
+	return rates, nil
+}
@@ -0,0 +1,175 @@
+package services
+
+import (
+	"github.com/PharmaKart/product-svc/internal/models"
+	"github.com/PharmaKart/product-svc/internal/repositories"
+	"github.com/PharmaKart/product-svc/pkg/errors"
+	"github.com/PharmaKart/product-svc/pkg/utils"
+	"github.com/google/uuid"
+)
+
+type BrandService interface {
+	CreateManufacturer(manufacturer *models.Manufacturer) (string, error)
+	GetManufacturer(id string) (*models.Manufacturer, error)
+	ListManufacturers(page, limit int32) ([]models.Manufacturer, int32, error)
+	UpdateManufacturer(id string, name string, country string, website string) error
+	DeleteManufacturer(id string) error
+	CreateBrand(brand *models.Brand) (string, error)
+	GetBrand(id string) (*models.Brand, error)
+	ListBrands(manufacturerID string, page, limit int32) ([]models.Brand, int32, error)
+	UpdateBrand(id string, manufacturerID string, name string) error
+	DeleteBrand(id string) error
+}
+
+type brandService struct {
+	BrandRepository        repositories.BrandRepository
+	ManufacturerRepository repositories.ManufacturerRepository
+}
+
+func NewBrandService(brandRepository repositories.BrandRepository, manufacturerRepository repositories.ManufacturerRepository) BrandService {
+	return &brandService{
+		BrandRepository:        brandRepository,
+		ManufacturerRepository: manufacturerRepository,
+	}
+}
+
+func (s *brandService) CreateManufacturer(manufacturer *models.Manufacturer) (string, error) {
+	// Validate the manufacturer input
+	if err := utils.ValidateManufacturerInput(manufacturer); err != nil {
+		return "", err
+	}
+
+	// Add the manufacturer to the database
+	manufacturerID, err := s.ManufacturerRepository.CreateManufacturer(manufacturer)
+	if err != nil {
+		return "", err
+	}
+	return manufacturerID, nil
+}
+
+func (s *brandService) GetManufacturer(id string) (*models.Manufacturer, error) {
+	manufacturer, err := s.ManufacturerRepository.GetManufacturer(id)
+	if err != nil {
+		return nil, err
+	}
+	return manufacturer, nil
+}
+
+func (s *brandService) ListManufacturers(page, limit int32) ([]models.Manufacturer, int32, error) {
+	manufacturers, total, err := s.ManufacturerRepository.ListManufacturers(page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	return manufacturers, total, nil
+}
+
+func (s *brandService) UpdateManufacturer(id string, name string, country string, website string) error {
+	// Get the manufacturer from the database
+	manufacturer, err := s.ManufacturerRepository.GetManufacturer(id)
+	if err != nil {
+		return err
+	}
+
+	// Update the manufacturer fields
+	manufacturer.Name = name
+	manufacturer.Country = &country
+	manufacturer.Website = &website
+
+	// Validate the manufacturer input
+	if err := utils.ValidateManufacturerInput(manufacturer); err != nil {
+		return err
+	}
+
+	// Update the manufacturer in the database
+	if err := s.ManufacturerRepository.UpdateManufacturer(manufacturer); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *brandService) DeleteManufacturer(id string) error {
+	// Delete the manufacturer from the database
+	if err := s.ManufacturerRepository.DeleteManufacturer(id); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *brandService) CreateBrand(brand *models.Brand) (string, error) {
+	// Validate the brand input
+	if err := utils.ValidateBrandInput(brand); err != nil {
+		return "", err
+	}
+
+	// Make sure the manufacturer exists
+	if _, err := s.ManufacturerRepository.GetManufacturer(brand.ManufacturerID.String()); err != nil {
+		return "", err
+	}
+
+	// Add the brand to the database
+	brandID, err := s.BrandRepository.CreateBrand(brand)
+	if err != nil {
+		return "", err
+	}
+	return brandID, nil
+}
+
+func (s *brandService) GetBrand(id string) (*models.Brand, error) {
+	brand, err := s.BrandRepository.GetBrand(id)
+	if err != nil {
+		return nil, err
+	}
+	return brand, nil
+}
+
+func (s *brandService) ListBrands(manufacturerID string, page, limit int32) ([]models.Brand, int32, error) {
+	if manufacturerID != "" {
+		if _, err := uuid.Parse(manufacturerID); err != nil {
+			return nil, 0, errors.NewValidationError("manufacturerId", "Invalid UUID: "+manufacturerID)
+		}
+	}
+
+	brands, total, err := s.BrandRepository.ListBrands(manufacturerID, page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	return brands, total, nil
+}
+
+func (s *brandService) UpdateBrand(id string, manufacturerID string, name string) error {
+	// Get the brand from the database
+	brand, err := s.BrandRepository.GetBrand(id)
+	if err != nil {
+		return err
+	}
+
+	// Update the brand fields, moving it to another manufacturer if requested
+	if manufacturerID != "" && manufacturerID != brand.ManufacturerID.String() {
+		manufacturer, err := s.ManufacturerRepository.GetManufacturer(manufacturerID)
+		if err != nil {
+			return err
+		}
+		brand.ManufacturerID = manufacturer.ID
+		brand.Manufacturer = manufacturer
+	}
+	brand.Name = name
+
+	// Validate the brand input
+	if err := utils.ValidateBrandInput(brand); err != nil {
+		return err
+	}
+
+	// Update the brand in the database
+	if err := s.BrandRepository.UpdateBrand(brand); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *brandService) DeleteBrand(id string) error {
+	// Delete the brand from the database
+	if err := s.BrandRepository.DeleteBrand(id); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/PharmaKart/product-svc/internal/models"
+	"github.com/PharmaKart/product-svc/internal/repositories"
+	"github.com/PharmaKart/product-svc/pkg/errors"
+	"github.com/google/uuid"
+)
+
+type InteractionService interface {
+	ImportInteractions(data []byte, replace bool) (int, error)
+	CheckInteractions(productIDs []string) ([]models.ProductInteraction, error)
+}
+
+type interactionService struct {
+	InteractionRepository repositories.InteractionRepository
+	IngredientRepository  repositories.IngredientRepository
+	ProductRepository     repositories.ProductRepository
+}
+
+func NewInteractionService(interactionRepository repositories.InteractionRepository, ingredientRepository repositories.IngredientRepository, productRepository repositories.ProductRepository) InteractionService {
+	return &interactionService{
+		InteractionRepository: interactionRepository,
+		IngredientRepository:  ingredientRepository,
+		ProductRepository:     productRepository,
+	}
+}
+
+// ImportInteractions loads interactions from a CSV file with the columns
+// ingredient_a,ingredient_b,severity,description. A header row is optional.
+func (s *interactionService) ImportInteractions(data []byte, replace bool) (int, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = 4
+	reader.TrimLeadingSpace = true
+
+	var interactions []models.IngredientInteraction
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, errors.NewBadRequestError(fmt.Sprintf("Invalid interactions file: %s", err))
+		}
+
+		if line == 1 && strings.EqualFold(strings.TrimSpace(record[0]), "ingredient_a") {
+			continue
+		}
+
+		ingredientA := strings.ToLower(strings.TrimSpace(record[0]))
+		ingredientB := strings.ToLower(strings.TrimSpace(record[1]))
+		severity := strings.ToLower(strings.TrimSpace(record[2]))
+		description := strings.TrimSpace(record[3])
+
+		if ingredientA == "" || ingredientB == "" {
+			return 0, errors.NewValidationError(fmt.Sprintf("line %d", line), "Both ingredient names are required")
+		}
+
+		if _, ok := models.InteractionSeverities[severity]; !ok {
+			return 0, errors.NewValidationError(fmt.Sprintf("line %d", line), "Severity must be one of minor, moderate, major, contraindicated")
+		}
+
+		interactions = append(interactions, models.IngredientInteraction{
+			IngredientA: &models.ActiveIngredient{Name: ingredientA},
+			IngredientB: &models.ActiveIngredient{Name: ingredientB},
+			Severity:    severity,
+			Description: description,
+		})
+	}
+
+	if len(interactions) == 0 {
+		return 0, errors.NewBadRequestError("Interactions file has no interactions")
+	}
+
+	if err := s.InteractionRepository.ImportInteractions(interactions, replace); err != nil {
+		return 0, err
+	}
+	return len(interactions), nil
+}
+
+// CheckInteractions returns the interactions between the active ingredients of every pair of products, most severe first
+func (s *interactionService) CheckInteractions(productIDs []string) ([]models.ProductInteraction, error) {
+	if len(productIDs) < 2 {
+		return nil, errors.NewValidationError("productIds", "At least two products are required")
+	}
+
+	// Collect which products contain each ingredient
+	productsByIngredient := make(map[uuid.UUID][]uuid.UUID)
+	var ingredientIDs []uuid.UUID
+	for _, productID := range productIDs {
+		product, err := s.ProductRepository.GetProduct(productID)
+		if err != nil {
+			return nil, err
+		}
+
+		ingredients, err := s.IngredientRepository.ListIngredientsByProductID(productID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, ingredient := range ingredients {
+			if _, ok := productsByIngredient[ingredient.IngredientID]; !ok {
+				ingredientIDs = append(ingredientIDs, ingredient.IngredientID)
+			}
+			productsByIngredient[ingredient.IngredientID] = append(productsByIngredient[ingredient.IngredientID], product.ID)
+		}
+	}
+
+	interactions, err := s.InteractionRepository.FindInteractions(ingredientIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Report each interaction for every pair of distinct products that contain its ingredients
+	var results []models.ProductInteraction
+	for _, interaction := range interactions {
+		seen := make(map[[2]uuid.UUID]bool)
+		for _, productA := range productsByIngredient[interaction.IngredientAID] {
+			for _, productB := range productsByIngredient[interaction.IngredientBID] {
+				pair := [2]uuid.UUID{productA, productB}
+				if productB.String() < productA.String() {
+					pair = [2]uuid.UUID{productB, productA}
+				}
+				if productA == productB || seen[pair] {
+					continue
+				}
+				seen[pair] = true
+
+				results = append(results, models.ProductInteraction{
+					ProductAID:  productA,
+					ProductBID:  productB,
+					IngredientA: interaction.IngredientA.Name,
+					IngredientB: interaction.IngredientB.Name,
+					Severity:    interaction.Severity,
+					Description: interaction.Description,
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return models.InteractionSeverities[results[i].Severity] > models.InteractionSeverities[results[j].Severity]
+	})
+
+	return results, nil
+}
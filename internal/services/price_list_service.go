@@ -0,0 +1,226 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/PharmaKart/product-svc/internal/models"
+	"github.com/PharmaKart/product-svc/internal/repositories"
+	"github.com/PharmaKart/product-svc/pkg/errors"
+	"github.com/PharmaKart/product-svc/pkg/utils"
+	"github.com/google/uuid"
+)
+
+type PriceListService interface {
+	CreatePriceList(priceList *models.PriceList) (string, error)
+	ListPriceLists(currency string, channel string) ([]models.PriceList, error)
+	DeletePriceList(id string) error
+	SetPrices(priceListID string, items []models.PriceListItem) error
+	RemovePrice(priceListID string, productID string) error
+	ListPrices(priceListID string, page, limit int32) ([]models.PriceListItem, int32, error)
+	ApplyPrices(products []*models.Product, pricing models.PriceContext) error
+}
+
+type priceListService struct {
+	PriceListRepository      repositories.PriceListRepository
+	ProductRepository        repositories.ProductRepository
+	ProductVariantRepository repositories.ProductVariantRepository
+}
+
+func NewPriceListService(priceListRepository repositories.PriceListRepository, productRepository repositories.ProductRepository, productVariantRepository repositories.ProductVariantRepository) PriceListService {
+	return &priceListService{
+		PriceListRepository:      priceListRepository,
+		ProductRepository:        productRepository,
+		ProductVariantRepository: productVariantRepository,
+	}
+}
+
+func (s *priceListService) CreatePriceList(priceList *models.PriceList) (string, error) {
+	priceList.Name = strings.TrimSpace(priceList.Name)
+	priceList.Currency = strings.ToUpper(strings.TrimSpace(priceList.Currency))
+	priceList.Channel = strings.ToLower(strings.TrimSpace(priceList.Channel))
+
+	// Validate the price list input
+	if err := utils.ValidatePriceListInput(priceList); err != nil {
+		return "", err
+	}
+
+	// Add the price list to the database
+	priceListID, err := s.PriceListRepository.CreatePriceList(priceList)
+	if err != nil {
+		return "", err
+	}
+	return priceListID, nil
+}
+
+func (s *priceListService) ListPriceLists(currency string, channel string) ([]models.PriceList, error) {
+	priceLists, err := s.PriceListRepository.ListPriceLists(strings.ToUpper(strings.TrimSpace(currency)), strings.ToLower(strings.TrimSpace(channel)))
+	if err != nil {
+		return nil, err
+	}
+	return priceLists, nil
+}
+
+func (s *priceListService) DeletePriceList(id string) error {
+	// Delete the price list from the database
+	if err := s.PriceListRepository.DeletePriceList(id); err != nil {
+		return err
+	}
+	return nil
+}
+
+// SetPrices sets the prices of products in a price list, other prices of the list are kept. Price lists only
+// price products without variants, variants keep their own price.
+func (s *priceListService) SetPrices(priceListID string, items []models.PriceListItem) error {
+	priceList, err := s.PriceListRepository.GetPriceList(priceListID)
+	if err != nil {
+		return err
+	}
+
+	// Validate the prices, a product may only be listed once
+	seen := make(map[uuid.UUID]bool, len(items))
+	for i := range items {
+		items[i].PriceListID = priceList.ID
+		if err := utils.ValidatePriceListItemInput(&items[i]); err != nil {
+			return err
+		}
+
+		if items[i].Currency != "" && strings.ToUpper(items[i].Currency) != priceList.Currency {
+			return errors.NewValidationError("currency", fmt.Sprintf("Prices of this price list must be in %s", priceList.Currency))
+		}
+
+		if seen[items[i].ProductID] {
+			return errors.NewValidationError("productId", fmt.Sprintf("Product with ID '%s' is listed more than once", items[i].ProductID))
+		}
+		seen[items[i].ProductID] = true
+
+		// Make sure the product exists
+		if _, err := s.ProductRepository.GetProduct(items[i].ProductID.String()); err != nil {
+			return err
+		}
+
+		variants, err := s.ProductVariantRepository.ListVariantsByProductID(items[i].ProductID.String())
+		if err != nil {
+			return err
+		}
+
+		if len(variants) > 0 {
+			return errors.NewValidationError("productId", fmt.Sprintf("Product with ID '%s' has variants and cannot be in a price list", items[i].ProductID))
+		}
+	}
+
+	// Save the prices to the database
+	if err := s.PriceListRepository.UpsertItems(items); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *priceListService) RemovePrice(priceListID string, productID string) error {
+	// Remove the price from the database
+	if err := s.PriceListRepository.DeleteItem(priceListID, productID); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *priceListService) ListPrices(priceListID string, page, limit int32) ([]models.PriceListItem, int32, error) {
+	priceList, err := s.PriceListRepository.GetPriceList(priceListID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	items, total, err := s.PriceListRepository.ListItems(priceListID, page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for i := range items {
+		items[i].Currency = priceList.Currency
+	}
+	return items, total, nil
+}
+
+// ApplyPrices sets the price that applies to each product in a price context. Products without a price in
+// the price list keep their base price when it is in the same currency, and get no price otherwise.
+func (s *priceListService) ApplyPrices(products []*models.Product, pricing models.PriceContext) error {
+	currency := strings.ToUpper(strings.TrimSpace(pricing.Currency))
+	channel := strings.ToLower(strings.TrimSpace(pricing.Channel))
+
+	var priceList *models.PriceList
+	if pricing.PriceListID != "" {
+		if _, err := uuid.Parse(pricing.PriceListID); err != nil {
+			return errors.NewValidationError("priceListId", "Invalid UUID: "+pricing.PriceListID)
+		}
+
+		list, err := s.PriceListRepository.GetPriceList(pricing.PriceListID)
+		if err != nil {
+			return err
+		}
+
+		if (currency != "" && currency != list.Currency) || (channel != "" && channel != list.Channel) {
+			return errors.NewValidationError("priceListId", fmt.Sprintf("Price list is for %s on channel '%s'", list.Currency, list.Channel))
+		}
+		priceList = list
+		currency = list.Currency
+	} else {
+		if currency == "" {
+			currency = models.DefaultCurrency
+		}
+
+		if channel == "" {
+			channel = models.ChannelWeb
+		}
+
+		validationErrors := make(map[string]string)
+		if _, ok := models.CurrencyExponents[currency]; !ok {
+			validationErrors["currency"] = "Currency must be one of CAD, USD, EUR, GBP, JPY"
+		}
+
+		if !models.SalesChannels[channel] {
+			validationErrors["channel"] = "Channel must be one of web, in_store, b2b_clinic"
+		}
+
+		if len(validationErrors) > 0 {
+			return errors.NewValidationErrors(validationErrors)
+		}
+
+		// Without a default list for the currency and channel only base prices apply
+		list, err := s.PriceListRepository.GetDefaultPriceList(currency, channel)
+		if err != nil {
+			if appErr, ok := errors.IsAppError(err); !ok || appErr.Type != errors.NotFoundError {
+				return err
+			}
+		}
+		priceList = list
+	}
+
+	prices := make(map[uuid.UUID]int64)
+	if priceList != nil {
+		ids := make([]uuid.UUID, 0, len(products))
+		for _, product := range products {
+			ids = append(ids, product.ID)
+		}
+
+		items, err := s.PriceListRepository.ListItemsByProductIDs(priceList.ID, ids)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range items {
+			prices[item.ProductID] = item.PriceMinor
+		}
+	}
+
+	for _, product := range products {
+		if amount, ok := prices[product.ID]; ok {
+			product.AppliedPrice = &models.AppliedPrice{
+				Price:       models.Money{Amount: amount, Currency: currency},
+				PriceListID: &priceList.ID,
+			}
+		} else if product.Currency == currency {
+			product.AppliedPrice = &models.AppliedPrice{Price: product.UnitPrice()}
+		}
+	}
+	return nil
+}
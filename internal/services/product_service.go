@@ -1,110 +1,764 @@
 package services
 
 import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
 	"github.com/PharmaKart/product-svc/internal/models"
 	"github.com/PharmaKart/product-svc/internal/repositories"
+	"github.com/PharmaKart/product-svc/pkg/errors"
 	"github.com/PharmaKart/product-svc/pkg/utils"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type ProductService interface {
-	CreateProduct(product *models.Product) (string, error)
-	GetProduct(id string) (*models.Product, error)
-	ListProducts(search string, filters models.Filter, sortBy string, sortOrder string, page, limit int32) ([]models.Product, int32, error)
-	UpdateProduct(id string, name string, description string, price float64, requiresPrescription bool, imageURL string) error
-	DeleteProduct(id string) error
+	CreateProduct(product *models.Product, actor string) (string, error)
+	GetProduct(id string, visibility models.ProductVisibility, locales []string, pricing models.PriceContext) (*models.Product, error)
+	ListProducts(search string, filter models.FilterExpression, options models.ProductListOptions, sortBy string, sortOrder string, page, limit int32) ([]models.Product, int32, error)
+	GetBrandFacets(search string, filter models.FilterExpression, options models.ProductListOptions) ([]models.BrandFacet, error)
+	UpdateProduct(id string, update *models.Product, overrideMargin bool, actor string) error
+	DeleteProduct(id string, actor string) error
+	RestoreProduct(id string, actor string) error
+	UpdateProductStatus(id string, status string, actor string) error
+	GetProductHistory(productID string, page, limit int32) ([]models.ProductVersion, int32, error)
+	GetProductVersion(productID string, version int32) (*models.ProductVersion, error)
+	SchedulePriceChange(change *models.PriceChange, overrideMargin bool, actor string) (string, error)
+	CancelPriceChange(id string) error
+	GetPriceHistory(productID string, variantID *uuid.UUID, includeScheduled bool, page, limit int32) ([]models.PriceChange, int32, error)
+	GetPriceAt(productID string, variantID *uuid.UUID, at time.Time) (*models.PriceChange, error)
 	UpdateStock(log *models.InventoryLog) error
-	GetInventoryLogs(productID string, filters models.Filter, sortBy string, sortOrder string, page, limit int32) ([]models.InventoryLog, int32, error)
+	GetInventoryLogs(productID string, filter models.FilterExpression, sortBy string, sortOrder string, page, limit int32) ([]models.InventoryLog, int32, error)
+	AddVariant(variant *models.ProductVariant, overrideMargin bool, actor string) (string, error)
+	UpdateVariant(id string, update *models.ProductVariant, overrideMargin bool, actor string) error
+	DeleteVariant(id string) error
+	AddIdentifier(identifier *models.ProductIdentifier) (string, error)
+	RemoveIdentifier(id string) error
+	GetProductByIdentifier(identifierType string, value string, visibility models.ProductVisibility, locales []string, pricing models.PriceContext) (*models.Product, *models.ProductIdentifier, error)
+	GetProductBySlug(slug string, visibility models.ProductVisibility, locales []string, pricing models.PriceContext) (*models.Product, bool, error)
+	SetTranslation(translation *models.ProductTranslation) error
+	RemoveTranslation(productID string, locale string) error
+	ListTranslations(productID string) ([]models.ProductTranslation, error)
+	AddRelation(relation *models.ProductRelation) (string, error)
+	RemoveRelation(id string) error
+	ListRelations(productID string, types []string, locales []string) ([]models.ProductRelation, error)
+	SetBundleComponents(bundleID string, components []models.BundleComponent) error
+	SetPriceTiers(productID string, tiers []models.PriceTier, overrideMargin bool) error
+	SetMarginRule(rule *models.MarginRule) error
+	RemoveMarginRule(id string) error
+	ListMarginRules() ([]models.MarginRule, error)
+	SetRegionRule(rule *models.ProductRegionRule) error
+	RemoveRegionRule(productID string, region string) error
+	ListRegionRules(productID string) ([]models.ProductRegionRule, error)
+	GetProductAvailabilityForRegion(productID string, region string) (*models.Product, *models.RegionAvailability, error)
+	AddImage(image *models.ProductImage) (string, error)
+	ReorderImages(productID string, imageIDs []string, primaryImageID string) error
+	RemoveImage(id string) error
+	SetIngredients(productID string, ingredients []models.ProductIngredient) error
+	FindEquivalents(productID string, limit int32) ([]models.Product, error)
 }
 
 type productService struct {
-	ProductRepository      repositories.ProductRepository
-	InventoryLogRepository repositories.InventoryLogRepository
+	ProductRepository            repositories.ProductRepository
+	InventoryLogRepository       repositories.InventoryLogRepository
+	ProductVariantRepository     repositories.ProductVariantRepository
+	ProductIdentifierRepository  repositories.ProductIdentifierRepository
+	BrandRepository              repositories.BrandRepository
+	IngredientRepository         repositories.IngredientRepository
+	ProductImageRepository       repositories.ProductImageRepository
+	ProductVersionRepository     repositories.ProductVersionRepository
+	ProductTranslationRepository repositories.ProductTranslationRepository
+	ProductRelationRepository    repositories.ProductRelationRepository
+	BundleRepository             repositories.BundleRepository
+	RegionRuleRepository         repositories.RegionRuleRepository
+	PriceChangeRepository        repositories.PriceChangeRepository
+	PriceTierRepository          repositories.PriceTierRepository
+	MarginRuleRepository         repositories.MarginRuleRepository
+	PriceListRepository          repositories.PriceListRepository
+	PriceListService             PriceListService
+	PromotionService             PromotionService
 }
 
-func NewProductService(productRepository repositories.ProductRepository, inventoryLogRepository repositories.InventoryLogRepository) ProductService {
+func NewProductService(productRepository repositories.ProductRepository, inventoryLogRepository repositories.InventoryLogRepository, productVariantRepository repositories.ProductVariantRepository, productIdentifierRepository repositories.ProductIdentifierRepository, brandRepository repositories.BrandRepository, ingredientRepository repositories.IngredientRepository, productImageRepository repositories.ProductImageRepository, productVersionRepository repositories.ProductVersionRepository, productTranslationRepository repositories.ProductTranslationRepository, productRelationRepository repositories.ProductRelationRepository, bundleRepository repositories.BundleRepository, regionRuleRepository repositories.RegionRuleRepository, priceChangeRepository repositories.PriceChangeRepository, priceTierRepository repositories.PriceTierRepository, marginRuleRepository repositories.MarginRuleRepository, priceListRepository repositories.PriceListRepository, priceListService PriceListService, promotionService PromotionService) ProductService {
 	return &productService{
-		ProductRepository:      productRepository,
-		InventoryLogRepository: inventoryLogRepository,
+		ProductRepository:            productRepository,
+		InventoryLogRepository:       inventoryLogRepository,
+		ProductVariantRepository:     productVariantRepository,
+		ProductIdentifierRepository:  productIdentifierRepository,
+		BrandRepository:              brandRepository,
+		IngredientRepository:         ingredientRepository,
+		ProductImageRepository:       productImageRepository,
+		ProductVersionRepository:     productVersionRepository,
+		ProductTranslationRepository: productTranslationRepository,
+		ProductRelationRepository:    productRelationRepository,
+		BundleRepository:             bundleRepository,
+		RegionRuleRepository:         regionRuleRepository,
+		PriceChangeRepository:        priceChangeRepository,
+		PriceTierRepository:          priceTierRepository,
+		MarginRuleRepository:         marginRuleRepository,
+		PriceListRepository:          priceListRepository,
+		PriceListService:             priceListService,
+		PromotionService:             promotionService,
 	}
 }
 
-func (s *productService) CreateProduct(product *models.Product) (string, error) {
+func (s *productService) CreateProduct(product *models.Product, actor string) (string, error) {
+	product.DosageForm = normalizeOption(product.DosageForm)
+	product.StrengthUnit = normalizeOption(product.StrengthUnit)
+	product.Route = normalizeOption(product.Route)
+	product.StorageCondition = normalizeOption(product.StorageCondition)
+
+	// Clients that only send the prescription flag get the matching class
+	if product.PrescriptionClass == "" {
+		product.PrescriptionClass = models.PrescriptionClassOTC
+		if product.RequiresPrescription {
+			product.PrescriptionClass = models.PrescriptionClassPrescription
+		}
+	}
+	product.RequiresPrescription = models.PrescriptionRequired(product.PrescriptionClass)
+
+	// New products are active unless they are created as drafts
+	if product.Status == "" {
+		product.Status = models.ProductStatusActive
+	}
+
+	if product.Currency == "" {
+		product.Currency = models.DefaultCurrency
+	}
+
 	// Validate the product input
 	if err := utils.ValidateProductInput(product); err != nil {
 		return "", err
 	}
+	product.SyncPrice()
+
+	if product.Status != models.ProductStatusDraft && product.Status != models.ProductStatusActive {
+		return "", errors.NewValidationError("status", "New products must be draft or active")
+	}
+
+	// Make sure the brand exists
+	if product.BrandID != nil {
+		if _, err := s.BrandRepository.GetBrand(product.BrandID.String()); err != nil {
+			return "", err
+		}
+	}
+
+	// Add the product to the database along with its price history and first version
+	var productID string
+	err := s.ProductRepository.Transaction(func(tx *gorm.DB) error {
+		products := s.ProductRepository.WithTx(tx)
+
+		var err error
+		if productID, err = products.CreateProduct(product); err != nil {
+			return err
+		}
+
+		slug, err := products.SetSlug(product.ID, product.Name)
+		if err != nil {
+			return err
+		}
+		product.Slug = &slug
+
+		if err := s.recordPriceChange(tx, product.ID, nil, product.UnitPrice(), actor); err != nil {
+			return err
+		}
 
-	// Add the product to the database
-	productID, err := s.ProductRepository.CreateProduct(product)
+		return s.recordVersion(tx, product.ID, models.ProductActionCreate, actor, nil, models.NewProductSnapshot(product))
+	})
 	if err != nil {
 		return "", err
 	}
 	return productID, nil
 }
 
-func (s *productService) GetProduct(id string) (*models.Product, error) {
-	product, err := s.ProductRepository.GetProduct(id)
+func (s *productService) GetProduct(id string, visibility models.ProductVisibility, locales []string, pricing models.PriceContext) (*models.Product, error) {
+	getProduct := s.ProductRepository.GetProduct
+	if visibility.IncludeDeleted {
+		getProduct = s.ProductRepository.GetProductIncludingDeleted
+	}
+
+	product, err := getProduct(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Drafts are hidden from callers that may not see them
+	if product.Status == models.ProductStatusDraft && !visibility.IncludeDrafts {
+		return nil, errors.NewNotFoundError(fmt.Sprintf("Product with ID '%s' not found", id))
+	}
+
+	// Attach the product variants
+	variants, err := s.ProductVariantRepository.ListVariantsByProductID(id)
+	if err != nil {
+		return nil, err
+	}
+	product.Variants = variants
+
+	// Attach the product identifiers
+	identifiers, err := s.ProductIdentifierRepository.ListIdentifiersByProductID(id)
+	if err != nil {
+		return nil, err
+	}
+	product.Identifiers = identifiers
+
+	// Attach the product ingredients
+	ingredients, err := s.IngredientRepository.ListIngredientsByProductID(id)
+	if err != nil {
+		return nil, err
+	}
+	product.Ingredients = ingredients
+
+	// Attach the quantity price tiers
+	tiers, err := s.PriceTierRepository.ListTiers([]uuid.UUID{product.ID})
 	if err != nil {
 		return nil, err
 	}
+	product.PriceTiers = tiers
+
+	// Derive the stock of a bundle from its components
+	if err := s.attachBundleComponents([]*models.Product{product}); err != nil {
+		return nil, err
+	}
+
+	localized := []*models.Product{product}
+
+	// Point customers of a discontinued product to its replacement
+	if product.Status == models.ProductStatusDiscontinued {
+		replacements, err := s.ProductRelationRepository.ListRelationsByProductID(id, []string{models.RelationReplacement})
+		if err != nil {
+			return nil, err
+		}
+
+		if len(replacements) > 0 {
+			product.Replacement = replacements[0].RelatedProduct
+			localized = append(localized, product.Replacement)
+		}
+	}
+
+	// Show the product content in the requested language
+	if err := s.localizeProducts(localized, locales); err != nil {
+		return nil, err
+	}
+
+	if err := s.applyPrices([]*models.Product{product}, pricing); err != nil {
+		return nil, err
+	}
+
 	return product, nil
 }
 
-func (s *productService) ListProducts(search string, filter models.Filter, sortBy string, sortOrder string, page, limit int32) ([]models.Product, int32, error) {
-	products, total, err := s.ProductRepository.ListProducts(search, filter, sortBy, sortOrder, page, limit)
+func (s *productService) ListProducts(search string, filter models.FilterExpression, options models.ProductListOptions, sortBy string, sortOrder string, page, limit int32) ([]models.Product, int32, error) {
+	options.Region = utils.NormalizeRegion(options.Region)
+	if err := validateProductListOptions(options); err != nil {
+		return nil, 0, err
+	}
+
+	products, total, err := s.ProductRepository.ListProducts(search, filter, options, sortBy, sortOrder, page, limit)
 	if err != nil {
 		return nil, 0, err
 	}
+
+	localized := make([]*models.Product, 0, len(products))
+	for i := range products {
+		localized = append(localized, &products[i])
+	}
+
+	if err := s.attachBundleComponents(localized); err != nil {
+		return nil, 0, err
+	}
+
+	if err := s.localizeProducts(localized, options.Locales); err != nil {
+		return nil, 0, err
+	}
+
+	// Flag how each product may be sold in the requested region
+	if options.Region != "" {
+		if err := s.attachRegionAvailability(localized, options.Region); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	if err := s.applyPrices(localized, options.Pricing); err != nil {
+		return nil, 0, err
+	}
 	return products, total, nil
 }
 
-func (s *productService) UpdateProduct(id string, name string, description string, price float64, requiresPrescription bool, imageURL string) error {
+// applyPrices sets the price of products in the price context of the caller, when one is requested, and the
+// sale price of products on promotion
+func (s *productService) applyPrices(products []*models.Product, pricing models.PriceContext) error {
+	if pricing.IsSet() {
+		if err := s.PriceListService.ApplyPrices(products, pricing); err != nil {
+			return err
+		}
+	}
+	return s.PromotionService.ApplySalePrices(products)
+}
+
+func (s *productService) GetBrandFacets(search string, filter models.FilterExpression, options models.ProductListOptions) ([]models.BrandFacet, error) {
+	options.Region = utils.NormalizeRegion(options.Region)
+	if err := validateProductListOptions(options); err != nil {
+		return nil, err
+	}
+
+	facets, err := s.ProductRepository.GetBrandFacets(search, filter, options)
+	if err != nil {
+		return nil, err
+	}
+	return facets, nil
+}
+
+func (s *productService) UpdateProduct(id string, update *models.Product, overrideMargin bool, actor string) error {
 	// Get the product from the database
 	product, err := s.ProductRepository.GetProduct(id)
 	if err != nil {
 		return err
 	}
+	previous := models.NewProductSnapshot(product)
 
 	// Update the product fields
-	product.Name = name
-	product.Description = &description
-	product.Price = price
-	product.RequiresPrescription = requiresPrescription
-	if imageURL != "" {
-		product.ImageURL = &imageURL
+	product.Name = update.Name
+	product.Description = update.Description
+	product.Price = update.Price
+	product.PriceMinor = update.PriceMinor
+
+	// Callers that cannot see the cost price keep it unchanged
+	costChanged := update.CostMinor != nil && (product.CostMinor == nil || *update.CostMinor != *product.CostMinor)
+	if update.CostMinor != nil {
+		product.CostMinor = update.CostMinor
+		product.CostCurrency = update.CostCurrency
+	}
+
+	// Variants and price tiers are priced in the currency of their product, so it only changes while there are none
+	if update.Currency != "" && update.Currency != product.Currency {
+		variants, err := s.ProductVariantRepository.ListVariantsByProductID(id)
+		if err != nil {
+			return err
+		}
+
+		if len(variants) > 0 {
+			return errors.NewBadRequestError("Currency of a product with variants cannot be changed")
+		}
+
+		tiers, err := s.PriceTierRepository.ListTiers([]uuid.UUID{product.ID})
+		if err != nil {
+			return err
+		}
+
+		if len(tiers) > 0 {
+			return errors.NewBadRequestError("Currency of a product with price tiers cannot be changed")
+		}
+		product.Currency = update.Currency
+	}
+
+	// The cost price is in the product currency, so a product with a cost only changes currency with a new cost
+	if product.Currency != previous.Currency && product.CostMinor != nil && update.CostMinor == nil {
+		return errors.NewValidationError("cost_price", "Cost price in the new currency is required to change the currency of a product with a cost price")
+	}
+
+	// Clients that only send the prescription flag keep the class unless the flag changes
+	if update.PrescriptionClass != "" {
+		product.PrescriptionClass = update.PrescriptionClass
+		product.ControlledSchedule = update.ControlledSchedule
+	} else if update.RequiresPrescription != models.PrescriptionRequired(product.PrescriptionClass) {
+		product.PrescriptionClass = models.PrescriptionClassOTC
+		if update.RequiresPrescription {
+			product.PrescriptionClass = models.PrescriptionClassPrescription
+		}
+		product.ControlledSchedule = nil
+	}
+	product.RequiresPrescription = models.PrescriptionRequired(product.PrescriptionClass)
+
+	// Clients that do not send the tax category keep it, an empty one clears it
+	if update.TaxCategory != nil {
+		product.TaxCategory = update.TaxCategory
+		if *update.TaxCategory == "" {
+			product.TaxCategory = nil
+		}
+	}
+
+	product.DosageForm = normalizeOption(update.DosageForm)
+	product.Strength = update.Strength
+	product.StrengthUnit = normalizeOption(update.StrengthUnit)
+	product.Route = normalizeOption(update.Route)
+	product.StorageCondition = normalizeOption(update.StorageCondition)
+	product.RequiresColdChain = update.RequiresColdChain
+
+	// Move the product to another brand, or clear it, if requested
+	if update.BrandID == nil {
+		product.BrandID = nil
+		product.Brand = nil
+	} else if product.BrandID == nil || *product.BrandID != *update.BrandID {
+		brand, err := s.BrandRepository.GetBrand(update.BrandID.String())
+		if err != nil {
+			return err
+		}
+		product.BrandID = &brand.ID
+		product.Brand = brand
 	}
 
 	// Validate the product input
 	if err := utils.ValidateProductInput(product); err != nil {
 		return err
 	}
+	product.SyncPrice()
+
+	// Clients that still send the single image URL replace the URL of the primary image
+	var imageURL string
+	if len(update.Images) > 0 {
+		if primary := product.PrimaryImage(); primary == nil || primary.URL != update.Images[0].URL {
+			imageURL = update.Images[0].URL
+			if err := utils.ValidateImageInput(&models.ProductImage{ProductID: product.ID, URL: imageURL}); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Only a change of price or cost can break a margin rule
+	if product.PriceMinor != previous.PriceMinor || product.Currency != previous.Currency || costChanged {
+		if err := s.checkMargin(product, "price", product.UnitPrice(), overrideMargin); err != nil {
+			return err
+		}
+	}
+
+	// Update the product in the database along with its price history and version
+	return s.ProductRepository.Transaction(func(tx *gorm.DB) error {
+		products := s.ProductRepository.WithTx(tx)
+
+		if err := products.UpdateProduct(product); err != nil {
+			return err
+		}
+
+		if imageURL != "" {
+			if err := s.ProductImageRepository.WithTx(tx).SetPrimaryImageURL(product.ID, imageURL); err != nil {
+				return err
+			}
+		}
+
+		if product.PriceMinor != previous.PriceMinor || product.Currency != previous.Currency {
+			if err := s.recordPriceChange(tx, product.ID, nil, product.UnitPrice(), actor); err != nil {
+				return err
+			}
+		}
+
+		// Slugs stay stable unless the name changes enough to change them
+		if product.Slug == nil || utils.Slugify(product.Name) != utils.Slugify(previous.Name) {
+			if _, err := products.SetSlug(product.ID, product.Name); err != nil {
+				return err
+			}
+		}
+
+		return s.recordVersion(tx, product.ID, models.ProductActionUpdate, actor, &previous, models.NewProductSnapshot(product))
+	})
+}
+
+// checkMargin applies the most specific margin rule of a product to one of its prices, the base price or the
+// price of a variant, tier or scheduled change. Products without a cost price have no margin to check.
+func (s *productService) checkMargin(product *models.Product, field string, price models.Money, overrideMargin bool) error {
+	if product.CostMinor == nil {
+		return nil
+	}
+
+	rules, err := s.MarginRuleRepository.ListRulesForProduct(product.ID, product.BrandID)
+	if err != nil {
+		return err
+	}
+
+	rule := models.MarginRuleFor(rules, product)
+	if rule == nil || rule.Allows(price, *product.CostMinor) {
+		return nil
+	}
+
+	// The cost is left out of the message, callers that may not see it can still be refused a price
+	message := fmt.Sprintf("Price of %s is below the minimum margin of %.2f%%", price, float64(rule.MinMarginBasisPoints)/100)
+	if rule.Enforcement == models.MarginEnforcementOverride {
+		if overrideMargin {
+			return nil
+		}
+		return errors.NewValidationError(field, message+", set the margin override to save it anyway")
+	}
+	return errors.NewValidationError(field, message)
+}
+
+func (s *productService) DeleteProduct(id string, actor string) error {
+	product, err := s.ProductRepository.GetProduct(id)
+	if err != nil {
+		return err
+	}
+	previous := models.NewProductSnapshot(product)
 
-	// Update the product in the database
-	if err := s.ProductRepository.UpdateProduct(product); err != nil {
+	current := previous
+	current.Deleted = true
+
+	// Delete the product from the database along with its version
+	return s.ProductRepository.Transaction(func(tx *gorm.DB) error {
+		if err := s.ProductRepository.WithTx(tx).DeleteProduct(id); err != nil {
+			return err
+		}
+		return s.recordVersion(tx, product.ID, models.ProductActionDelete, actor, &previous, current)
+	})
+}
+
+func (s *productService) RestoreProduct(id string, actor string) error {
+	product, err := s.ProductRepository.GetProductIncludingDeleted(id)
+	if err != nil {
 		return err
 	}
-	return nil
+
+	if !product.DeletedAt.Valid {
+		return errors.NewBadRequestError(fmt.Sprintf("Product with ID '%s' is not deleted", id))
+	}
+
+	// Another product may have taken the name since this one was deleted
+	existingProduct, err := s.ProductRepository.GetProductByName(product.Name)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); !ok || appErr.Type != errors.NotFoundError {
+			return err
+		}
+	}
+
+	if existingProduct != nil {
+		return errors.NewConflictError(fmt.Sprintf("Product with name '%s' already exists", product.Name))
+	}
+
+	previous := models.NewProductSnapshot(product)
+	current := previous
+	current.Deleted = false
+
+	// Restore the product in the database along with its version
+	return s.ProductRepository.Transaction(func(tx *gorm.DB) error {
+		if err := s.ProductRepository.WithTx(tx).RestoreProduct(id); err != nil {
+			return err
+		}
+		return s.recordVersion(tx, product.ID, models.ProductActionRestore, actor, &previous, current)
+	})
+}
+
+func (s *productService) UpdateProductStatus(id string, status string, actor string) error {
+	product, err := s.ProductRepository.GetProduct(id)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := models.ProductStatusTransitions[status]; !ok {
+		return errors.NewValidationError("status", "Status must be one of draft, active, discontinued, recalled")
+	}
+
+	// Only allow the transitions of the product lifecycle
+	if !slices.Contains(models.ProductStatusTransitions[product.Status], status) {
+		return errors.NewBadRequestError(fmt.Sprintf("Product status cannot change from '%s' to '%s'", product.Status, status))
+	}
+
+	if status == models.ProductStatusActive && product.EffectiveStatus() == models.ProductStatusRecalled {
+		return errors.NewBadRequestError("Product has an active recall, resolve the recall before activating the product")
+	}
+
+	previous := models.NewProductSnapshot(product)
+	current := previous
+	current.Status = status
+
+	// Update the status in the database along with its version
+	return s.ProductRepository.Transaction(func(tx *gorm.DB) error {
+		if err := s.ProductRepository.WithTx(tx).UpdateStatus(id, status); err != nil {
+			return err
+		}
+		return s.recordVersion(tx, product.ID, models.ProductActionStatusChange, actor, &previous, current)
+	})
+}
+
+func (s *productService) GetProductHistory(productID string, page, limit int32) ([]models.ProductVersion, int32, error) {
+	versions, total, err := s.ProductVersionRepository.ListVersions(productID, page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Versions outlive purged products, so only a product without any may not exist
+	if total == 0 {
+		if _, err := s.ProductRepository.GetProductIncludingDeleted(productID); err != nil {
+			return nil, 0, err
+		}
+	}
+	return versions, total, nil
+}
+
+func (s *productService) GetProductVersion(productID string, version int32) (*models.ProductVersion, error) {
+	productVersion, err := s.ProductVersionRepository.GetVersion(productID, version)
+	if err != nil {
+		return nil, err
+	}
+	return productVersion, nil
 }
 
-func (s *productService) DeleteProduct(id string) error {
-	// Delete the product from the database
-	if err := s.ProductRepository.DeleteProduct(id); err != nil {
+// recordVersion stores the state of a product after a change, along with the fields that changed, in the
+// transaction of the change
+func (s *productService) recordVersion(tx *gorm.DB, productID uuid.UUID, action string, actor string, previous *models.ProductSnapshot, current models.ProductSnapshot) error {
+	version, err := models.NewProductVersion(productID, action, actor, previous, current)
+	if err != nil {
+		return errors.NewInternalError(err)
+	}
+	return s.ProductVersionRepository.WithTx(tx).CreateVersion(version)
+}
+
+// recordPriceChange adds a price that takes effect now to the price history of a product or variant, in the
+// transaction of the change
+func (s *productService) recordPriceChange(tx *gorm.DB, productID uuid.UUID, variantID *uuid.UUID, price models.Money, actor string) error {
+	_, err := s.PriceChangeRepository.WithTx(tx).CreateChange(&models.PriceChange{
+		ProductID:   productID,
+		VariantID:   variantID,
+		PriceMinor:  price.Amount,
+		Currency:    price.Currency,
+		EffectiveAt: time.Now(),
+		Status:      models.PriceChangeApplied,
+		Actor:       actor,
+	})
+	return err
+}
+
+// SchedulePriceChange schedules the price of a product or variant to change at a future time
+func (s *productService) SchedulePriceChange(change *models.PriceChange, overrideMargin bool, actor string) (string, error) {
+	if err := utils.ValidatePriceChangeInput(change, time.Now()); err != nil {
+		return "", err
+	}
+
+	product, err := s.ProductRepository.GetProduct(change.ProductID.String())
+	if err != nil {
+		return "", err
+	}
+
+	if change.VariantID != nil {
+		variant, err := s.ProductVariantRepository.GetVariant(change.VariantID.String())
+		if err != nil {
+			return "", err
+		}
+
+		if variant.ProductID != change.ProductID {
+			return "", errors.NewValidationError("variantId", "Variant does not belong to the product")
+		}
+	}
+
+	// Scheduled prices are in the currency of the product
+	change.Currency = strings.ToUpper(strings.TrimSpace(change.Currency))
+	if change.Currency == "" {
+		change.Currency = product.Currency
+	} else if change.Currency != product.Currency {
+		return "", errors.NewValidationError("currency", fmt.Sprintf("Prices of this product must be in %s", product.Currency))
+	}
+
+	// Scheduled prices apply unattended, so they meet the margin rules when they are scheduled
+	if err := s.checkMargin(product, "price", change.Price(), overrideMargin); err != nil {
+		return "", err
+	}
+
+	change.Status = models.PriceChangeScheduled
+	change.Actor = actor
+
+	changeID, err := s.PriceChangeRepository.CreateChange(change)
+	if err != nil {
+		return "", err
+	}
+	return changeID, nil
+}
+
+func (s *productService) CancelPriceChange(id string) error {
+	if err := s.PriceChangeRepository.CancelChange(id); err != nil {
 		return err
 	}
 	return nil
 }
 
+func (s *productService) GetPriceHistory(productID string, variantID *uuid.UUID, includeScheduled bool, page, limit int32) ([]models.PriceChange, int32, error) {
+	changes, total, err := s.PriceChangeRepository.ListChanges(productID, variantID, includeScheduled, page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// The price history outlives purged products, so only a product without any may not exist
+	if total == 0 {
+		if _, err := s.ProductRepository.GetProductIncludingDeleted(productID); err != nil {
+			return nil, 0, err
+		}
+	}
+	return changes, total, nil
+}
+
+// GetPriceAt returns the price change that was in effect for a product or variant at a point in time, also for
+// products that have since been purged
+func (s *productService) GetPriceAt(productID string, variantID *uuid.UUID, at time.Time) (*models.PriceChange, error) {
+	change, err := s.PriceChangeRepository.GetPriceAt(productID, variantID, at)
+	if err != nil {
+		return nil, err
+	}
+	return change, nil
+}
+
 func (s *productService) UpdateStock(log *models.InventoryLog) error {
 	// Validate the inventory input
 	if err := utils.ValidateInventoryInput(log); err != nil {
 		return err
 	}
 
-	// Update the stock in the database
-	if err := s.ProductRepository.UpdateStock(log.ProductID, log.QuantityChange); err != nil {
+	product, err := s.ProductRepository.GetProduct(log.ProductID.String())
+	if err != nil {
 		return err
 	}
 
+	// Only active products can be sold, and never while they or the ordered variant are recalled
+	if log.ChangeType == "order_placed" {
+		if status := product.EffectiveStatus(); status != models.ProductStatusActive {
+			return errors.NewBadRequestError(fmt.Sprintf("Product with status '%s' cannot be ordered", status))
+		}
+
+		for _, recall := range product.Recalls {
+			if log.VariantID != nil && recall.VariantID != nil && *recall.VariantID == *log.VariantID {
+				return errors.NewBadRequestError(fmt.Sprintf("Variant with ID '%s' is recalled and cannot be ordered", log.VariantID))
+			}
+		}
+
+		// Orders shipping to a region must respect the rules of the product there
+		if log.Region != nil {
+			if err := s.checkRegionOrder(product, log); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Bundles have no stock of their own, orders move the stock of their components
+	if product.IsBundle {
+		return s.updateBundleStock(product, log)
+	}
+
+	// Variant stock is tracked on the variant, the parent product keeps its own stock
+	if log.VariantID != nil {
+		variant, err := s.ProductVariantRepository.GetVariant(log.VariantID.String())
+		if err != nil {
+			return err
+		}
+
+		if variant.ProductID != log.ProductID {
+			return errors.NewValidationError("variantId", "Variant does not belong to the product")
+		}
+
+		if err := s.ProductVariantRepository.UpdateStock(variant.ID, log.QuantityChange); err != nil {
+			return err
+		}
+	} else {
+		variants, err := s.ProductVariantRepository.ListVariantsByProductID(log.ProductID.String())
+		if err != nil {
+			return err
+		}
+
+		if len(variants) > 0 {
+			return errors.NewValidationError("variantId", "Variant ID is required for products with variants")
+		}
+
+		// Update the stock in the database
+		if err := s.ProductRepository.UpdateStock(log.ProductID, log.QuantityChange); err != nil {
+			return err
+		}
+	}
+
 	// Log the inventory change
 	if err := s.InventoryLogRepository.LogChange(log); err != nil {
 		return err
@@ -113,10 +767,896 @@ func (s *productService) UpdateStock(log *models.InventoryLog) error {
 	return nil
 }
 
-func (s *productService) GetInventoryLogs(productID string, filter models.Filter, sortBy string, sortOrder string, page, limit int32) ([]models.InventoryLog, int32, error) {
+func (s *productService) GetInventoryLogs(productID string, filter models.FilterExpression, sortBy string, sortOrder string, page, limit int32) ([]models.InventoryLog, int32, error) {
 	logs, total, err := s.InventoryLogRepository.GetLogsByProductID(productID, filter, sortBy, sortOrder, page, limit)
 	if err != nil {
 		return nil, 0, err
 	}
 	return logs, total, nil
 }
+
+func (s *productService) AddVariant(variant *models.ProductVariant, overrideMargin bool, actor string) (string, error) {
+	// Make sure the parent product exists
+	product, err := s.ProductRepository.GetProduct(variant.ProductID.String())
+	if err != nil {
+		return "", err
+	}
+
+	// Validate the variant input
+	if err := utils.ValidateVariantInput(variant, product.Currency); err != nil {
+		return "", err
+	}
+	variant.SyncPrice(product.Currency)
+
+	if product.IsBundle {
+		return "", errors.NewBadRequestError("Bundles cannot have variants")
+	}
+
+	tiers, err := s.PriceTierRepository.ListTiers([]uuid.UUID{product.ID})
+	if err != nil {
+		return "", err
+	}
+
+	if len(tiers) > 0 {
+		return "", errors.NewBadRequestError("Products with price tiers cannot have variants")
+	}
+
+	// Price lists price products without variants, so a listed product cannot gain any
+	listed, err := s.PriceListRepository.IsListed(product.ID)
+	if err != nil {
+		return "", err
+	}
+
+	if listed {
+		return "", errors.NewBadRequestError("Products in a price list cannot have variants")
+	}
+
+	if err := s.checkMargin(product, "price", models.Money{Amount: variant.PriceMinor, Currency: product.Currency}, overrideMargin); err != nil {
+		return "", err
+	}
+
+	// Add the variant to the database along with its price history
+	var variantID string
+	err = s.ProductRepository.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if variantID, err = s.ProductVariantRepository.WithTx(tx).CreateVariant(variant); err != nil {
+			return err
+		}
+		return s.recordPriceChange(tx, product.ID, &variant.ID, models.Money{Amount: variant.PriceMinor, Currency: product.Currency}, actor)
+	})
+	if err != nil {
+		return "", err
+	}
+	return variantID, nil
+}
+
+func (s *productService) UpdateVariant(id string, update *models.ProductVariant, overrideMargin bool, actor string) error {
+	// Get the variant from the database
+	variant, err := s.ProductVariantRepository.GetVariant(id)
+	if err != nil {
+		return err
+	}
+
+	product, err := s.ProductRepository.GetProduct(variant.ProductID.String())
+	if err != nil {
+		return err
+	}
+
+	previousPrice := variant.PriceMinor
+
+	// Update the variant fields
+	variant.SKU = update.SKU
+	variant.Name = update.Name
+	variant.Price = update.Price
+	variant.PriceMinor = update.PriceMinor
+
+	// Validate the variant input
+	if err := utils.ValidateVariantInput(variant, product.Currency); err != nil {
+		return err
+	}
+	variant.SyncPrice(product.Currency)
+
+	if variant.PriceMinor != previousPrice {
+		if err := s.checkMargin(product, "price", models.Money{Amount: variant.PriceMinor, Currency: product.Currency}, overrideMargin); err != nil {
+			return err
+		}
+	}
+
+	// Update the variant in the database along with its price history
+	return s.ProductRepository.Transaction(func(tx *gorm.DB) error {
+		if err := s.ProductVariantRepository.WithTx(tx).UpdateVariant(variant); err != nil {
+			return err
+		}
+
+		if variant.PriceMinor != previousPrice {
+			return s.recordPriceChange(tx, product.ID, &variant.ID, models.Money{Amount: variant.PriceMinor, Currency: product.Currency}, actor)
+		}
+		return nil
+	})
+}
+
+func (s *productService) DeleteVariant(id string) error {
+	// Delete the variant from the database
+	if err := s.ProductVariantRepository.DeleteVariant(id); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *productService) AddIdentifier(identifier *models.ProductIdentifier) (string, error) {
+	// Validate and normalize the identifier
+	identifierType, value, err := utils.NormalizeIdentifier(identifier.Type, identifier.Value)
+	if err != nil {
+		return "", err
+	}
+	identifier.Type = identifierType
+	identifier.Value = value
+
+	// Make sure the product, and the variant if any, exist
+	if _, err := s.ProductRepository.GetProduct(identifier.ProductID.String()); err != nil {
+		return "", err
+	}
+
+	if identifier.VariantID != nil {
+		variant, err := s.ProductVariantRepository.GetVariant(identifier.VariantID.String())
+		if err != nil {
+			return "", err
+		}
+
+		if variant.ProductID != identifier.ProductID {
+			return "", errors.NewValidationError("variantId", "Variant does not belong to the product")
+		}
+	}
+
+	// Add the identifier to the database
+	identifierID, err := s.ProductIdentifierRepository.CreateIdentifier(identifier)
+	if err != nil {
+		return "", err
+	}
+	return identifierID, nil
+}
+
+func (s *productService) RemoveIdentifier(id string) error {
+	// Delete the identifier from the database
+	if err := s.ProductIdentifierRepository.DeleteIdentifier(id); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *productService) GetProductByIdentifier(identifierType string, value string, visibility models.ProductVisibility, locales []string, pricing models.PriceContext) (*models.Product, *models.ProductIdentifier, error) {
+	// Normalize the identifier so any accepted format matches the stored one
+	identifierType, value, err := utils.NormalizeIdentifier(identifierType, value)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	identifier, err := s.ProductIdentifierRepository.GetIdentifier(identifierType, value)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	product, err := s.GetProduct(identifier.ProductID.String(), visibility, locales, pricing)
+	if err != nil {
+		return nil, nil, err
+	}
+	return product, identifier, nil
+}
+
+// GetProductBySlug finds a product by its current slug, or by a previous slug in which case redirected is true
+func (s *productService) GetProductBySlug(slug string, visibility models.ProductVisibility, locales []string, pricing models.PriceContext) (*models.Product, bool, error) {
+	slug = strings.ToLower(strings.TrimSpace(slug))
+
+	product, err := s.ProductRepository.GetProductBySlug(slug)
+	if err == nil {
+		product, err = s.GetProduct(product.ID.String(), visibility, locales, pricing)
+		return product, false, err
+	}
+
+	if appErr, ok := errors.IsAppError(err); !ok || appErr.Type != errors.NotFoundError {
+		return nil, false, err
+	}
+
+	redirect, err := s.ProductRepository.GetSlugRedirect(slug)
+	if err != nil {
+		return nil, false, err
+	}
+
+	product, err = s.GetProduct(redirect.ProductID.String(), visibility, locales, pricing)
+	if err != nil {
+		return nil, false, err
+	}
+	return product, true, nil
+}
+
+func (s *productService) SetTranslation(translation *models.ProductTranslation) error {
+	translation.Locale = utils.NormalizeLocale(translation.Locale)
+
+	// Validate the translation input
+	if err := utils.ValidateTranslationInput(translation); err != nil {
+		return err
+	}
+
+	// Make sure the product exists
+	if _, err := s.ProductRepository.GetProduct(translation.ProductID.String()); err != nil {
+		return err
+	}
+
+	// Save the translation to the database
+	if err := s.ProductTranslationRepository.UpsertTranslation(translation); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *productService) RemoveTranslation(productID string, locale string) error {
+	// Remove the translation from the database
+	if err := s.ProductTranslationRepository.DeleteTranslation(productID, utils.NormalizeLocale(locale)); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *productService) ListTranslations(productID string) ([]models.ProductTranslation, error) {
+	if _, err := s.ProductRepository.GetProduct(productID); err != nil {
+		return nil, err
+	}
+
+	translations, err := s.ProductTranslationRepository.ListTranslationsByProductID(productID)
+	if err != nil {
+		return nil, err
+	}
+	return translations, nil
+}
+
+func (s *productService) AddRelation(relation *models.ProductRelation) (string, error) {
+	relation.Type = strings.ToLower(strings.TrimSpace(relation.Type))
+
+	// Validate the relation input
+	if err := utils.ValidateRelationInput(relation); err != nil {
+		return "", err
+	}
+
+	// Make sure both products exist
+	if _, err := s.ProductRepository.GetProduct(relation.ProductID.String()); err != nil {
+		return "", err
+	}
+
+	if _, err := s.ProductRepository.GetProduct(relation.RelatedProductID.String()); err != nil {
+		return "", err
+	}
+
+	// Add the relation to the database
+	relationID, err := s.ProductRelationRepository.CreateRelation(relation)
+	if err != nil {
+		return "", err
+	}
+	return relationID, nil
+}
+
+func (s *productService) RemoveRelation(id string) error {
+	// Remove the relation from the database
+	if err := s.ProductRelationRepository.DeleteRelation(id); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ListRelations returns the relations of a product to products that can be bought, optionally of some types only
+func (s *productService) ListRelations(productID string, types []string, locales []string) ([]models.ProductRelation, error) {
+	for _, relationType := range types {
+		if !models.RelationTypes[relationType] {
+			return nil, errors.NewValidationError("types", "Invalid relation type: "+relationType)
+		}
+	}
+
+	relations, err := s.ProductRelationRepository.ListRelationsByProductID(productID, types)
+	if err != nil {
+		return nil, err
+	}
+
+	related := make([]*models.Product, 0, len(relations))
+	for i := range relations {
+		related = append(related, relations[i].RelatedProduct)
+	}
+
+	if err := s.localizeProducts(related, locales); err != nil {
+		return nil, err
+	}
+	return relations, nil
+}
+
+// SetBundleComponents makes a product a bundle of the given components, or a regular product again when there are none
+func (s *productService) SetBundleComponents(bundleID string, components []models.BundleComponent) error {
+	bundle, err := s.ProductRepository.GetProduct(bundleID)
+	if err != nil {
+		return err
+	}
+
+	if len(components) > 0 {
+		variants, err := s.ProductVariantRepository.ListVariantsByProductID(bundleID)
+		if err != nil {
+			return err
+		}
+
+		if len(variants) > 0 {
+			return errors.NewBadRequestError("Products with variants cannot be bundles")
+		}
+
+		isComponent, err := s.BundleRepository.IsComponent(bundle.ID)
+		if err != nil {
+			return err
+		}
+
+		if isComponent {
+			return errors.NewBadRequestError("Components of other bundles cannot be bundles")
+		}
+	}
+
+	validationErrors := make(map[string]string)
+	seen := make(map[string]bool)
+	for i, component := range components {
+		field := fmt.Sprintf("components[%d]", i)
+
+		if component.Quantity <= 0 {
+			validationErrors[field] = "Quantity must be greater than 0"
+			continue
+		}
+
+		if component.ComponentID == bundle.ID {
+			validationErrors[field] = "A bundle cannot contain itself"
+			continue
+		}
+
+		key := component.ComponentID.String()
+		if component.VariantID != nil {
+			key += "/" + component.VariantID.String()
+		}
+		if seen[key] {
+			validationErrors[field] = "Component is listed more than once"
+			continue
+		}
+		seen[key] = true
+
+		product, err := s.ProductRepository.GetProduct(component.ComponentID.String())
+		if err != nil {
+			if appErr, ok := errors.IsAppError(err); ok && appErr.Type == errors.NotFoundError {
+				validationErrors[field] = appErr.Message
+				continue
+			}
+			return err
+		}
+
+		if product.IsBundle {
+			validationErrors[field] = "Bundles cannot contain other bundles"
+			continue
+		}
+
+		if component.VariantID != nil {
+			variant, err := s.ProductVariantRepository.GetVariant(component.VariantID.String())
+			if err != nil {
+				if appErr, ok := errors.IsAppError(err); ok && appErr.Type == errors.NotFoundError {
+					validationErrors[field] = appErr.Message
+					continue
+				}
+				return err
+			}
+
+			if variant.ProductID != component.ComponentID {
+				validationErrors[field] = "Variant does not belong to the component"
+			}
+			continue
+		}
+
+		variants, err := s.ProductVariantRepository.ListVariantsByProductID(component.ComponentID.String())
+		if err != nil {
+			return err
+		}
+
+		if len(variants) > 0 {
+			validationErrors[field] = "Variant ID is required for components with variants"
+		}
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.NewValidationErrors(validationErrors)
+	}
+
+	// Replace the components in the database
+	if err := s.BundleRepository.ReplaceComponents(bundle.ID, components); err != nil {
+		return err
+	}
+	return nil
+}
+
+// SetPriceTiers replaces the quantity price tiers of a product, in the currency of the product. Tiers only
+// price products without variants, variants keep their own price.
+func (s *productService) SetPriceTiers(productID string, tiers []models.PriceTier, overrideMargin bool) error {
+	product, err := s.ProductRepository.GetProduct(productID)
+	if err != nil {
+		return err
+	}
+
+	if len(tiers) > 0 {
+		variants, err := s.ProductVariantRepository.ListVariantsByProductID(productID)
+		if err != nil {
+			return err
+		}
+
+		if len(variants) > 0 {
+			return errors.NewBadRequestError("Products with variants cannot have price tiers")
+		}
+	}
+
+	for i := range tiers {
+		currency := strings.ToUpper(strings.TrimSpace(tiers[i].Currency))
+		if currency != "" && currency != product.Currency {
+			return errors.NewValidationError(fmt.Sprintf("tiers[%d]", i), fmt.Sprintf("Prices of this product must be in %s", product.Currency))
+		}
+	}
+
+	models.SortPriceTiers(tiers)
+	if err := utils.ValidatePriceTiersInput(tiers); err != nil {
+		return err
+	}
+
+	for i, tier := range tiers {
+		if err := s.checkMargin(product, fmt.Sprintf("tiers[%d]", i), models.Money{Amount: tier.PriceMinor, Currency: product.Currency}, overrideMargin); err != nil {
+			return err
+		}
+	}
+
+	// Replace the tiers in the database
+	if err := s.PriceTierRepository.ReplaceTiers(product.ID, tiers); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *productService) SetMarginRule(rule *models.MarginRule) error {
+	rule.TargetType = strings.ToLower(strings.TrimSpace(rule.TargetType))
+	rule.Enforcement = strings.ToLower(strings.TrimSpace(rule.Enforcement))
+
+	// Validate the rule input
+	if err := utils.ValidateMarginRuleInput(rule); err != nil {
+		return err
+	}
+
+	// Make sure the targeted product or brand exists
+	var err error
+	switch rule.TargetType {
+	case models.MarginRuleTargetProduct:
+		_, err = s.ProductRepository.GetProduct(rule.TargetID.String())
+	case models.MarginRuleTargetBrand:
+		_, err = s.BrandRepository.GetBrand(rule.TargetID.String())
+	}
+	if err != nil {
+		return err
+	}
+
+	// Save the rule to the database
+	if err := s.MarginRuleRepository.UpsertRule(rule); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *productService) RemoveMarginRule(id string) error {
+	// Remove the rule from the database
+	if err := s.MarginRuleRepository.DeleteRule(id); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *productService) ListMarginRules() ([]models.MarginRule, error) {
+	rules, err := s.MarginRuleRepository.ListRules()
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (s *productService) SetRegionRule(rule *models.ProductRegionRule) error {
+	rule.Region = utils.NormalizeRegion(rule.Region)
+	rule.Rule = strings.ToLower(strings.TrimSpace(rule.Rule))
+
+	// Validate the rule input
+	if err := utils.ValidateRegionRuleInput(rule); err != nil {
+		return err
+	}
+
+	// Make sure the product exists
+	if _, err := s.ProductRepository.GetProduct(rule.ProductID.String()); err != nil {
+		return err
+	}
+
+	// Save the rule to the database
+	if err := s.RegionRuleRepository.UpsertRule(rule); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *productService) RemoveRegionRule(productID string, region string) error {
+	// Remove the rule from the database
+	if err := s.RegionRuleRepository.DeleteRule(productID, utils.NormalizeRegion(region)); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *productService) ListRegionRules(productID string) ([]models.ProductRegionRule, error) {
+	if _, err := s.ProductRepository.GetProduct(productID); err != nil {
+		return nil, err
+	}
+
+	rules, err := s.RegionRuleRepository.ListRulesByProductID(productID)
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (s *productService) GetProductAvailabilityForRegion(productID string, region string) (*models.Product, *models.RegionAvailability, error) {
+	region = utils.NormalizeRegion(region)
+	if !utils.IsValidRegion(region) {
+		return nil, nil, errors.NewValidationError("region", "Region must be an ISO 3166 country code with an optional subdivision, e.g. CA or CA-ON")
+	}
+
+	product, err := s.ProductRepository.GetProduct(productID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Drafts are never available to customers
+	if product.Status == models.ProductStatusDraft {
+		return nil, nil, errors.NewNotFoundError(fmt.Sprintf("Product with ID '%s' not found", productID))
+	}
+
+	if err := s.attachRegionAvailability([]*models.Product{product}, region); err != nil {
+		return nil, nil, err
+	}
+	return product, product.RegionAvailability, nil
+}
+
+// attachRegionAvailability applies the rules of the products for a region
+func (s *productService) attachRegionAvailability(products []*models.Product, region string) error {
+	ids := make([]uuid.UUID, 0, len(products))
+	for _, product := range products {
+		ids = append(ids, product.ID)
+	}
+
+	rules, err := s.RegionRuleRepository.ListRules(ids, models.RegionFallbacks(region))
+	if err != nil {
+		return err
+	}
+
+	for _, product := range products {
+		availability := models.NewRegionAvailability(product, region, rules)
+		product.RegionAvailability = &availability
+	}
+	return nil
+}
+
+// checkRegionOrder rejects orders of products that are not sold in the region of the order, or that exceed its quantity limit
+func (s *productService) checkRegionOrder(product *models.Product, log *models.InventoryLog) error {
+	region := utils.NormalizeRegion(*log.Region)
+	if !utils.IsValidRegion(region) {
+		return errors.NewValidationError("region", "Region must be an ISO 3166 country code with an optional subdivision, e.g. CA or CA-ON")
+	}
+	log.Region = &region
+
+	if err := s.attachRegionAvailability([]*models.Product{product}, region); err != nil {
+		return err
+	}
+
+	availability := product.RegionAvailability
+	if availability.Rule != nil && availability.Rule.Rule == models.RegionRuleNotSold {
+		return errors.NewBadRequestError(fmt.Sprintf("Product with ID '%s' is not sold in region '%s'", product.ID, region))
+	}
+
+	quantity := log.QuantityChange
+	if quantity < 0 {
+		quantity = -quantity
+	}
+
+	if availability.MaxQuantity != nil && quantity > *availability.MaxQuantity {
+		return errors.NewBadRequestError(fmt.Sprintf("At most %d units of product with ID '%s' can be ordered in region '%s'", *availability.MaxQuantity, product.ID, region))
+	}
+	return nil
+}
+
+// attachBundleComponents loads the components of the bundles among the products and derives their stock
+func (s *productService) attachBundleComponents(products []*models.Product) error {
+	var bundleIDs []uuid.UUID
+	for _, product := range products {
+		if product.IsBundle {
+			bundleIDs = append(bundleIDs, product.ID)
+		}
+	}
+
+	if len(bundleIDs) == 0 {
+		return nil
+	}
+
+	components, err := s.BundleRepository.ListComponents(bundleIDs)
+	if err != nil {
+		return err
+	}
+
+	componentsByBundle := make(map[uuid.UUID][]models.BundleComponent)
+	for _, component := range components {
+		componentsByBundle[component.BundleID] = append(componentsByBundle[component.BundleID], component)
+	}
+
+	for _, product := range products {
+		if product.IsBundle {
+			product.Components = componentsByBundle[product.ID]
+			product.Stock = models.BundleStock(product.Components)
+		}
+	}
+
+	return nil
+}
+
+// updateBundleStock moves the stock of every component of a bundle by the quantity of the bundle change,
+// logging the change of each component
+func (s *productService) updateBundleStock(bundle *models.Product, log *models.InventoryLog) error {
+	if log.ChangeType == "stock_added" {
+		return errors.NewBadRequestError("Bundle stock is derived from its components, add stock to the components instead")
+	}
+
+	if log.VariantID != nil {
+		return errors.NewValidationError("variantId", "Bundles have no variants")
+	}
+
+	components, err := s.BundleRepository.ListComponents([]uuid.UUID{bundle.ID})
+	if err != nil {
+		return err
+	}
+
+	if len(components) == 0 {
+		return errors.NewBadRequestError("Bundle has no components")
+	}
+
+	logs := make([]models.InventoryLog, 0, len(components))
+	for _, component := range components {
+		if log.ChangeType == "order_placed" && component.AvailableStock() == 0 {
+			return errors.NewBadRequestError(fmt.Sprintf("Component with ID '%s' is not available", component.ComponentID))
+		}
+
+		// Each component must be sold in the region of the order, in the quantity the order takes of it
+		if log.ChangeType == "order_placed" && log.Region != nil {
+			componentLog := models.InventoryLog{Region: log.Region, QuantityChange: log.QuantityChange * component.Quantity}
+			if err := s.checkRegionOrder(component.Component, &componentLog); err != nil {
+				return err
+			}
+		}
+
+		logs = append(logs, models.InventoryLog{
+			ProductID:      component.ComponentID,
+			VariantID:      component.VariantID,
+			OrderID:        log.OrderID,
+			Region:         log.Region,
+			ChangeType:     log.ChangeType,
+			QuantityChange: log.QuantityChange * component.Quantity,
+		})
+	}
+
+	// Update the component stock and log the changes in one transaction
+	if err := s.BundleRepository.UpdateComponentStock(logs); err != nil {
+		return err
+	}
+	return nil
+}
+
+// localizeProducts replaces the name and description of each product with its translation in the first of the
+// locales it has one for. Products without a translation keep their own content in the default locale.
+func (s *productService) localizeProducts(products []*models.Product, locales []string) error {
+	for _, product := range products {
+		product.Locale = models.DefaultLocale
+	}
+
+	if len(locales) == 0 || len(products) == 0 {
+		return nil
+	}
+
+	productIDs := make([]uuid.UUID, 0, len(products))
+	for _, product := range products {
+		productIDs = append(productIDs, product.ID)
+	}
+
+	translations, err := s.ProductTranslationRepository.ListTranslations(productIDs, locales)
+	if err != nil {
+		return err
+	}
+
+	best := make(map[uuid.UUID]models.ProductTranslation)
+	for _, translation := range translations {
+		current, ok := best[translation.ProductID]
+		if !ok || slices.Index(locales, translation.Locale) < slices.Index(locales, current.Locale) {
+			best[translation.ProductID] = translation
+		}
+	}
+
+	for _, product := range products {
+		translation, ok := best[product.ID]
+		if !ok {
+			continue
+		}
+
+		product.Name = translation.Name
+		if translation.Description != nil {
+			product.Description = translation.Description
+		}
+		product.Locale = translation.Locale
+	}
+
+	return nil
+}
+
+func (s *productService) AddImage(image *models.ProductImage) (string, error) {
+	// Validate the image input
+	if err := utils.ValidateImageInput(image); err != nil {
+		return "", err
+	}
+
+	// Make sure the product exists
+	if _, err := s.ProductRepository.GetProduct(image.ProductID.String()); err != nil {
+		return "", err
+	}
+
+	// Add the image to the database
+	imageID, err := s.ProductImageRepository.CreateImage(image)
+	if err != nil {
+		return "", err
+	}
+	return imageID, nil
+}
+
+func (s *productService) ReorderImages(productID string, imageIDs []string, primaryImageID string) error {
+	product, err := s.ProductRepository.GetProduct(productID)
+	if err != nil {
+		return err
+	}
+
+	ids := make([]uuid.UUID, 0, len(imageIDs))
+	for _, imageID := range imageIDs {
+		id, err := uuid.Parse(imageID)
+		if err != nil {
+			return errors.NewValidationError("imageIds", "Invalid UUID: "+imageID)
+		}
+		ids = append(ids, id)
+	}
+
+	var primaryID *uuid.UUID
+	if primaryImageID != "" {
+		id, err := uuid.Parse(primaryImageID)
+		if err != nil {
+			return errors.NewValidationError("primaryImageId", "Invalid UUID: "+primaryImageID)
+		}
+		primaryID = &id
+	}
+
+	// Reorder the images in the database
+	if err := s.ProductImageRepository.ReorderImages(product.ID, ids, primaryID); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *productService) RemoveImage(id string) error {
+	// Delete the image from the database
+	if err := s.ProductImageRepository.DeleteImage(id); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *productService) SetIngredients(productID string, ingredients []models.ProductIngredient) error {
+	// Make sure the product exists
+	product, err := s.ProductRepository.GetProduct(productID)
+	if err != nil {
+		return err
+	}
+
+	// Validate the ingredients, an ingredient may only be listed once
+	seen := make(map[string]bool, len(ingredients))
+	for i := range ingredients {
+		if ingredients[i].Ingredient != nil {
+			ingredients[i].Ingredient.Name = strings.ToLower(strings.TrimSpace(ingredients[i].Ingredient.Name))
+		}
+		ingredients[i].StrengthUnit = strings.ToLower(strings.TrimSpace(ingredients[i].StrengthUnit))
+
+		if err := utils.ValidateIngredientInput(&ingredients[i]); err != nil {
+			return err
+		}
+
+		if seen[ingredients[i].Ingredient.Name] {
+			return errors.NewValidationError("ingredientName", "Ingredient '"+ingredients[i].Ingredient.Name+"' is listed more than once")
+		}
+		seen[ingredients[i].Ingredient.Name] = true
+	}
+
+	// Replace the ingredients in the database
+	if err := s.IngredientRepository.ReplaceProductIngredients(product.ID, ingredients); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *productService) FindEquivalents(productID string, limit int32) ([]models.Product, error) {
+	product, err := s.ProductRepository.GetProduct(productID)
+	if err != nil {
+		return nil, err
+	}
+
+	ingredients, err := s.IngredientRepository.ListIngredientsByProductID(productID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Products need a dosage form and ingredients to be compared
+	if product.DosageForm == nil || len(ingredients) == 0 {
+		return nil, errors.NewBadRequestError("Product has no dosage form or active ingredients to match on")
+	}
+
+	products, err := s.IngredientRepository.FindEquivalentProducts(product.ID, *product.DosageForm, product.Currency, ingredients, limit)
+	if err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+// normalizeOption lowercases an enumerated product field, treating an empty one as unset
+func normalizeOption(value *string) *string {
+	if value == nil || strings.TrimSpace(*value) == "" {
+		return nil
+	}
+
+	normalized := strings.ToLower(strings.TrimSpace(*value))
+	return &normalized
+}
+
+// validateProductListOptions makes sure the ID options of a product listing are valid UUIDs and
+// that only callers allowed to see drafts list them
+func validateProductListOptions(options models.ProductListOptions) error {
+	for _, status := range options.Statuses {
+		if _, ok := models.ProductStatusTransitions[status]; !ok {
+			return errors.NewValidationError("statuses", "Invalid status: "+status)
+		}
+
+		if status == models.ProductStatusDraft && !options.Visibility.IncludeDrafts {
+			return errors.NewAuthError("Only admins can list draft products")
+		}
+	}
+
+	validationErrors := make(map[string]string)
+	if options.Region != "" && !utils.IsValidRegion(options.Region) {
+		validationErrors["region"] = "Region must be an ISO 3166 country code with an optional subdivision, e.g. CA or CA-ON"
+	}
+
+	for _, prescriptionClass := range options.PrescriptionClasses {
+		if !models.PrescriptionClasses[prescriptionClass] {
+			validationErrors["prescriptionClasses"] = "Invalid prescription class: " + prescriptionClass
+		}
+	}
+
+	if options.BrandID != "" {
+		if _, err := uuid.Parse(options.BrandID); err != nil {
+			validationErrors["brandId"] = "Invalid UUID: " + options.BrandID
+		}
+	}
+
+	if options.ManufacturerID != "" {
+		if _, err := uuid.Parse(options.ManufacturerID); err != nil {
+			validationErrors["manufacturerId"] = "Invalid UUID: " + options.ManufacturerID
+		}
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.NewValidationErrors(validationErrors)
+	}
+	return nil
+}
@@ -0,0 +1,306 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/PharmaKart/product-svc/internal/models"
+	"github.com/PharmaKart/product-svc/internal/repositories"
+	"github.com/PharmaKart/product-svc/pkg/errors"
+	"github.com/PharmaKart/product-svc/pkg/utils"
+	"github.com/google/uuid"
+)
+
+type PromotionService interface {
+	CreatePromotion(promotion *models.Promotion) (string, error)
+	ListPromotions(activeOnly bool, page, limit int32) ([]models.Promotion, int32, error)
+	DeletePromotion(id string) error
+	PriceQuote(items []models.QuoteItem, pricing models.PriceContext) (*models.PriceQuote, error)
+	ApplySalePrices(products []*models.Product) error
+}
+
+type promotionService struct {
+	PromotionRepository      repositories.PromotionRepository
+	ProductRepository        repositories.ProductRepository
+	ProductVariantRepository repositories.ProductVariantRepository
+	BrandRepository          repositories.BrandRepository
+	PriceTierRepository      repositories.PriceTierRepository
+	PriceListService         PriceListService
+}
+
+func NewPromotionService(promotionRepository repositories.PromotionRepository, productRepository repositories.ProductRepository, productVariantRepository repositories.ProductVariantRepository, brandRepository repositories.BrandRepository, priceTierRepository repositories.PriceTierRepository, priceListService PriceListService) PromotionService {
+	return &promotionService{
+		PromotionRepository:      promotionRepository,
+		ProductRepository:        productRepository,
+		ProductVariantRepository: productVariantRepository,
+		BrandRepository:          brandRepository,
+		PriceTierRepository:      priceTierRepository,
+		PriceListService:         priceListService,
+	}
+}
+
+func (s *promotionService) CreatePromotion(promotion *models.Promotion) (string, error) {
+	promotion.Name = strings.TrimSpace(promotion.Name)
+	promotion.Type = strings.ToLower(strings.TrimSpace(promotion.Type))
+	if promotion.Currency != nil {
+		currency := strings.ToUpper(strings.TrimSpace(*promotion.Currency))
+		promotion.Currency = &currency
+	}
+	for i := range promotion.Targets {
+		promotion.Targets[i].TargetType = strings.ToLower(strings.TrimSpace(promotion.Targets[i].TargetType))
+	}
+
+	// Validate the promotion input
+	if err := utils.ValidatePromotionInput(promotion); err != nil {
+		return "", err
+	}
+
+	// Make sure the targeted products and brands exist
+	for _, target := range promotion.Targets {
+		var err error
+		switch target.TargetType {
+		case models.PromotionTargetProduct:
+			_, err = s.ProductRepository.GetProduct(target.TargetID.String())
+		case models.PromotionTargetBrand:
+			_, err = s.BrandRepository.GetBrand(target.TargetID.String())
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+
+	// Add the promotion to the database
+	promotionID, err := s.PromotionRepository.CreatePromotion(promotion)
+	if err != nil {
+		return "", err
+	}
+	return promotionID, nil
+}
+
+func (s *promotionService) ListPromotions(activeOnly bool, page, limit int32) ([]models.Promotion, int32, error) {
+	var activeAt *time.Time
+	if activeOnly {
+		now := time.Now()
+		activeAt = &now
+	}
+
+	promotions, total, err := s.PromotionRepository.ListPromotions(activeAt, page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	return promotions, total, nil
+}
+
+func (s *promotionService) DeletePromotion(id string) error {
+	// Delete the promotion from the database
+	if err := s.PromotionRepository.DeletePromotion(id); err != nil {
+		return err
+	}
+	return nil
+}
+
+// PriceQuote prices a basket in a price context and applies the best active promotion to each line
+func (s *promotionService) PriceQuote(items []models.QuoteItem, pricing models.PriceContext) (*models.PriceQuote, error) {
+	if len(items) == 0 {
+		return nil, errors.NewValidationError("items", "At least one item is required")
+	}
+
+	// Load the products of the basket, only active products can be sold
+	products := make(map[uuid.UUID]*models.Product)
+	var priced []*models.Product
+	for _, item := range items {
+		if item.Quantity <= 0 {
+			return nil, errors.NewValidationError("quantity", "Quantity must be greater than 0")
+		}
+
+		if _, ok := products[item.ProductID]; ok {
+			continue
+		}
+
+		product, err := s.ProductRepository.GetProduct(item.ProductID.String())
+		if err != nil {
+			return nil, err
+		}
+
+		if status := product.EffectiveStatus(); status != models.ProductStatusActive {
+			return nil, errors.NewBadRequestError(fmt.Sprintf("Product with ID '%s' has status '%s' and cannot be quoted", product.ID, status))
+		}
+
+		products[product.ID] = product
+		priced = append(priced, product)
+	}
+
+	if err := s.PriceListService.ApplyPrices(priced, pricing); err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, 0, len(priced))
+	var brandIDs []uuid.UUID
+	for _, product := range priced {
+		ids = append(ids, product.ID)
+		if product.BrandID != nil {
+			brandIDs = append(brandIDs, *product.BrandID)
+		}
+	}
+
+	// Price tiers apply to the quantity of a product over the whole basket
+	priceTiers, err := s.PriceTierRepository.ListTiers(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	tiers := make(map[uuid.UUID][]models.PriceTier)
+	for _, tier := range priceTiers {
+		tiers[tier.ProductID] = append(tiers[tier.ProductID], tier)
+	}
+
+	quantities := make(map[uuid.UUID]int)
+	for _, item := range items {
+		if item.VariantID == nil {
+			quantities[item.ProductID] += item.Quantity
+		}
+	}
+
+	// Price the lines, which must share one currency
+	quote := &models.PriceQuote{}
+	for _, item := range items {
+		product := products[item.ProductID]
+		unitPrice, err := s.unitPrice(product, item.VariantID)
+		if err != nil {
+			return nil, err
+		}
+
+		// A tier only lowers the price, a price list may already sell the product for less
+		var tier *models.PriceTier
+		if item.VariantID == nil && unitPrice.Currency == product.Currency {
+			tier = models.TierFor(tiers[product.ID], quantities[product.ID])
+			if tier != nil && tier.PriceMinor < unitPrice.Amount {
+				unitPrice.Amount = tier.PriceMinor
+			} else {
+				tier = nil
+			}
+		}
+
+		if quote.Total.Currency == "" {
+			quote.Subtotal.Currency = unitPrice.Currency
+			quote.Discount.Currency = unitPrice.Currency
+			quote.Total.Currency = unitPrice.Currency
+		} else if unitPrice.Currency != quote.Total.Currency {
+			return nil, errors.NewValidationError("currency", fmt.Sprintf("Product with ID '%s' is priced in %s, not %s", product.ID, unitPrice.Currency, quote.Total.Currency))
+		}
+
+		subtotal := models.Money{Amount: unitPrice.Amount * int64(item.Quantity), Currency: unitPrice.Currency}
+		quote.Lines = append(quote.Lines, models.QuoteLine{
+			Item:      item,
+			UnitPrice: unitPrice,
+			PriceTier: tier,
+			Subtotal:  subtotal,
+			Discount:  models.Money{Currency: unitPrice.Currency},
+			Total:     subtotal,
+		})
+	}
+
+	promotions, err := s.PromotionRepository.ListActivePromotions(ids, brandIDs, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply the best promotion of each line and total the discount of each promotion
+	totals := make(map[uuid.UUID]int)
+	for i := range quote.Lines {
+		line := &quote.Lines[i]
+		promotion, discount := models.BestPromotion(promotions, products[line.Item.ProductID], line.UnitPrice, line.Item.Quantity)
+		if promotion != nil {
+			line.Discount = discount
+			line.Total.Amount -= discount.Amount
+			line.Promotions = append(line.Promotions, models.AppliedPromotion{
+				PromotionID: promotion.ID,
+				Name:        promotion.Name,
+				Discount:    discount,
+			})
+
+			if index, ok := totals[promotion.ID]; ok {
+				quote.Promotions[index].Discount.Amount += discount.Amount
+			} else {
+				totals[promotion.ID] = len(quote.Promotions)
+				quote.Promotions = append(quote.Promotions, line.Promotions[0])
+			}
+		}
+
+		quote.Subtotal.Amount += line.Subtotal.Amount
+		quote.Discount.Amount += line.Discount.Amount
+		quote.Total.Amount += line.Total.Amount
+	}
+
+	return quote, nil
+}
+
+// unitPrice returns the price of a unit of a product in the price context it was loaded in. Variants have
+// their own price in the currency of the product, which price lists do not change.
+func (s *promotionService) unitPrice(product *models.Product, variantID *uuid.UUID) (models.Money, error) {
+	if variantID != nil {
+		variant, err := s.ProductVariantRepository.GetVariant(variantID.String())
+		if err != nil {
+			return models.Money{}, err
+		}
+
+		if variant.ProductID != product.ID {
+			return models.Money{}, errors.NewValidationError("variantId", "Variant does not belong to the product")
+		}
+
+		// A price context that does not sell the product at its base price has no price for its variants
+		if product.AppliedPrice == nil || product.AppliedPrice.PriceListID != nil {
+			return models.Money{}, errors.NewBadRequestError(fmt.Sprintf("Variants of product with ID '%s' are only priced in %s without a price list", product.ID, product.Currency))
+		}
+		return models.Money{Amount: variant.PriceMinor, Currency: product.Currency}, nil
+	}
+
+	variants, err := s.ProductVariantRepository.ListVariantsByProductID(product.ID.String())
+	if err != nil {
+		return models.Money{}, err
+	}
+
+	if len(variants) > 0 {
+		return models.Money{}, errors.NewValidationError("variantId", "Variant ID is required for products with variants")
+	}
+
+	if product.AppliedPrice == nil {
+		return models.Money{}, errors.NewBadRequestError(fmt.Sprintf("Product with ID '%s' has no price in the requested currency", product.ID))
+	}
+	return product.AppliedPrice.Price, nil
+}
+
+// ApplySalePrices sets the sale price of products that an active promotion discounts by itself, from the
+// price of their price context when one was applied. Buy X get Y promotions only discount baskets.
+func (s *promotionService) ApplySalePrices(products []*models.Product) error {
+	ids := make([]uuid.UUID, 0, len(products))
+	var brandIDs []uuid.UUID
+	for _, product := range products {
+		ids = append(ids, product.ID)
+		if product.BrandID != nil {
+			brandIDs = append(brandIDs, *product.BrandID)
+		}
+	}
+
+	promotions, err := s.PromotionRepository.ListActivePromotions(ids, brandIDs, time.Now())
+	if err != nil {
+		return err
+	}
+
+	for _, product := range products {
+		price := product.UnitPrice()
+		if product.AppliedPrice != nil {
+			price = product.AppliedPrice.Price
+		}
+
+		promotion, discount := models.BestPromotion(promotions, product, price, 1)
+		if promotion != nil {
+			product.SalePrice = &models.SalePrice{
+				Price:       models.Money{Amount: price.Amount - discount.Amount, Currency: price.Currency},
+				PromotionID: promotion.ID,
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/PharmaKart/product-svc/internal/models"
+	"github.com/PharmaKart/product-svc/internal/repositories"
+	"github.com/PharmaKart/product-svc/pkg/errors"
+	"github.com/PharmaKart/product-svc/pkg/utils"
+)
+
+type RecallService interface {
+	CreateRecall(recall *models.Recall) (string, error)
+	ResolveRecall(id string) error
+	ListRecalls(productID string, activeOnly bool) ([]models.Recall, error)
+	GetRecallImpact(id string, page, limit int32) (*models.RecallImpact, error)
+}
+
+type recallService struct {
+	RecallRepository         repositories.RecallRepository
+	ProductRepository        repositories.ProductRepository
+	ProductVariantRepository repositories.ProductVariantRepository
+	InventoryLogRepository   repositories.InventoryLogRepository
+}
+
+func NewRecallService(recallRepository repositories.RecallRepository, productRepository repositories.ProductRepository, productVariantRepository repositories.ProductVariantRepository, inventoryLogRepository repositories.InventoryLogRepository) RecallService {
+	return &recallService{
+		RecallRepository:         recallRepository,
+		ProductRepository:        productRepository,
+		ProductVariantRepository: productVariantRepository,
+		InventoryLogRepository:   inventoryLogRepository,
+	}
+}
+
+// CreateRecall records a recall, which takes effect immediately unless an effective date is given
+func (s *recallService) CreateRecall(recall *models.Recall) (string, error) {
+	if err := utils.ValidateRecallInput(recall); err != nil {
+		return "", err
+	}
+
+	// Deleted products may still be in customers' hands
+	if _, err := s.ProductRepository.GetProductIncludingDeleted(recall.ProductID.String()); err != nil {
+		return "", err
+	}
+
+	if recall.VariantID != nil {
+		variant, err := s.ProductVariantRepository.GetVariant(recall.VariantID.String())
+		if err != nil {
+			return "", err
+		}
+
+		if variant.ProductID != recall.ProductID {
+			return "", errors.NewValidationError("variantId", "Variant does not belong to the product")
+		}
+	}
+
+	if recall.EffectiveDate.IsZero() {
+		recall.EffectiveDate = time.Now()
+	}
+
+	recallID, err := s.RecallRepository.CreateRecall(recall)
+	if err != nil {
+		return "", err
+	}
+
+	utils.Info("Product recall created", map[string]interface{}{
+		"recallId":      recallID,
+		"productId":     recall.ProductID.String(),
+		"severityClass": recall.SeverityClass,
+	})
+
+	return recallID, nil
+}
+
+func (s *recallService) ResolveRecall(id string) error {
+	recall, err := s.RecallRepository.GetRecall(id)
+	if err != nil {
+		return err
+	}
+
+	if recall.ResolvedAt != nil {
+		return errors.NewBadRequestError(fmt.Sprintf("Recall with ID '%s' is already resolved", id))
+	}
+
+	// Resolve the recall in the database
+	if err := s.RecallRepository.ResolveRecall(id, time.Now()); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *recallService) ListRecalls(productID string, activeOnly bool) ([]models.Recall, error) {
+	if _, err := s.ProductRepository.GetProductIncludingDeleted(productID); err != nil {
+		return nil, err
+	}
+
+	recalls, err := s.RecallRepository.ListRecallsByProductID(productID, activeOnly)
+	if err != nil {
+		return nil, err
+	}
+	return recalls, nil
+}
+
+// GetRecallImpact returns the recalled stock still on hand and the orders placed for it, for customer outreach
+func (s *recallService) GetRecallImpact(id string, page, limit int32) (*models.RecallImpact, error) {
+	recall, err := s.RecallRepository.GetRecall(id)
+	if err != nil {
+		return nil, err
+	}
+
+	impact := &models.RecallImpact{Recall: recall}
+
+	// Products with variants keep their stock on the variants
+	if recall.VariantID != nil {
+		variant, err := s.ProductVariantRepository.GetVariant(recall.VariantID.String())
+		if err != nil {
+			return nil, err
+		}
+		impact.OnHandStock = variant.Stock
+	} else {
+		product, err := s.ProductRepository.GetProductIncludingDeleted(recall.ProductID.String())
+		if err != nil {
+			return nil, err
+		}
+
+		variants, err := s.ProductVariantRepository.ListVariantsByProductID(recall.ProductID.String())
+		if err != nil {
+			return nil, err
+		}
+
+		impact.OnHandStock = product.Stock
+		for _, variant := range variants {
+			impact.OnHandStock += variant.Stock
+		}
+	}
+
+	impact.Orders, impact.TotalOrders, err = s.InventoryLogRepository.GetOrderLogs(recall.ProductID, recall.VariantID, page, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return impact, nil
+}
@@ -0,0 +1,170 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/PharmaKart/product-svc/internal/models"
+	"github.com/PharmaKart/product-svc/internal/repositories"
+	"github.com/PharmaKart/product-svc/pkg/errors"
+	"github.com/PharmaKart/product-svc/pkg/utils"
+	"github.com/google/uuid"
+)
+
+type TaxService interface {
+	SetTaxRate(rate *models.TaxRate) error
+	RemoveTaxRate(region string, taxCategory string) error
+	ListTaxRates(region string) ([]models.TaxRate, error)
+	CalculateTax(items []models.QuoteItem, region string, pricing models.PriceContext) (*models.TaxCalculation, error)
+}
+
+type taxService struct {
+	TaxRateRepository    repositories.TaxRateRepository
+	ProductRepository    repositories.ProductRepository
+	RegionRuleRepository repositories.RegionRuleRepository
+	PromotionService     PromotionService
+}
+
+func NewTaxService(taxRateRepository repositories.TaxRateRepository, productRepository repositories.ProductRepository, regionRuleRepository repositories.RegionRuleRepository, promotionService PromotionService) TaxService {
+	return &taxService{
+		TaxRateRepository:    taxRateRepository,
+		ProductRepository:    productRepository,
+		RegionRuleRepository: regionRuleRepository,
+		PromotionService:     promotionService,
+	}
+}
+
+func (s *taxService) SetTaxRate(rate *models.TaxRate) error {
+	rate.Region = utils.NormalizeRegion(rate.Region)
+	rate.TaxCategory = strings.ToLower(strings.TrimSpace(rate.TaxCategory))
+
+	// Validate the tax rate input
+	if err := utils.ValidateTaxRateInput(rate); err != nil {
+		return err
+	}
+
+	// Save the rate in the database
+	if err := s.TaxRateRepository.UpsertRate(rate); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *taxService) RemoveTaxRate(region string, taxCategory string) error {
+	// Delete the rate from the database
+	if err := s.TaxRateRepository.DeleteRate(utils.NormalizeRegion(region), strings.ToLower(strings.TrimSpace(taxCategory))); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ListTaxRates returns the rates that apply in a region, or every rate when no region is given
+func (s *taxService) ListTaxRates(region string) ([]models.TaxRate, error) {
+	var regions []string
+	if region = utils.NormalizeRegion(region); region != "" {
+		if !utils.IsValidRegion(region) {
+			return nil, errors.NewValidationError("region", "Region must be an ISO 3166 country code with an optional subdivision, e.g. CA or CA-ON")
+		}
+		regions = models.RegionFallbacks(region)
+	}
+
+	rates, err := s.TaxRateRepository.ListRates(regions)
+	if err != nil {
+		return nil, err
+	}
+	return rates, nil
+}
+
+// CalculateTax prices a basket like a price quote and taxes every line total in the region. Products
+// without a tax category take the default of their prescription class in the region.
+func (s *taxService) CalculateTax(items []models.QuoteItem, region string, pricing models.PriceContext) (*models.TaxCalculation, error) {
+	region = utils.NormalizeRegion(region)
+	if !utils.IsValidRegion(region) {
+		return nil, errors.NewValidationError("region", "Region must be an ISO 3166 country code with an optional subdivision, e.g. CA or CA-ON")
+	}
+
+	quote, err := s.PromotionService.PriceQuote(items, pricing)
+	if err != nil {
+		return nil, err
+	}
+
+	// Find the tax category of every product in the region
+	regions := models.RegionFallbacks(region)
+	products := make(map[uuid.UUID]*models.Product)
+	var ids []uuid.UUID
+	for _, line := range quote.Lines {
+		if _, ok := products[line.Item.ProductID]; ok {
+			continue
+		}
+
+		product, err := s.ProductRepository.GetProduct(line.Item.ProductID.String())
+		if err != nil {
+			return nil, err
+		}
+		products[product.ID] = product
+		ids = append(ids, product.ID)
+	}
+
+	rules, err := s.RegionRuleRepository.ListRules(ids, regions)
+	if err != nil {
+		return nil, err
+	}
+
+	taxCategories := make(map[uuid.UUID]string)
+	for id, product := range products {
+		if product.TaxCategory != nil {
+			taxCategories[id] = *product.TaxCategory
+			continue
+		}
+
+		availability := models.NewRegionAvailability(product, region, rules)
+		taxCategories[id] = models.DefaultTaxCategory(availability.PrescriptionClass)
+	}
+
+	rates, err := s.TaxRateRepository.ListRates(regions)
+	if err != nil {
+		return nil, err
+	}
+
+	// Tax the lines and total the tax of each category
+	currency := quote.Total.Currency
+	calculation := &models.TaxCalculation{
+		Region:   region,
+		Subtotal: quote.Total,
+		Tax:      models.Money{Currency: currency},
+	}
+	totals := make(map[string]int)
+	for _, line := range quote.Lines {
+		taxCategory := taxCategories[line.Item.ProductID]
+		rate := models.TaxRateFor(rates, region, taxCategory)
+		if rate == nil {
+			return nil, errors.NewBadRequestError(fmt.Sprintf("No %s tax rate for region '%s'", taxCategory, region))
+		}
+
+		tax := rate.Tax(line.Total)
+		calculation.Lines = append(calculation.Lines, models.TaxLine{
+			QuoteLine:        line,
+			TaxCategory:      taxCategory,
+			RateMilliPercent: rate.RateMilliPercent,
+			Tax:              tax,
+		})
+
+		index, ok := totals[taxCategory]
+		if !ok {
+			index = len(calculation.Categories)
+			totals[taxCategory] = index
+			calculation.Categories = append(calculation.Categories, models.TaxCategoryTotal{
+				TaxCategory:      taxCategory,
+				RateMilliPercent: rate.RateMilliPercent,
+				Taxable:          models.Money{Currency: currency},
+				Tax:              models.Money{Currency: currency},
+			})
+		}
+		calculation.Categories[index].Taxable.Amount += line.Total.Amount
+		calculation.Categories[index].Tax.Amount += tax.Amount
+		calculation.Tax.Amount += tax.Amount
+	}
+
+	calculation.Total = models.Money{Amount: calculation.Subtotal.Amount + calculation.Tax.Amount, Currency: currency}
+	return calculation, nil
+}
@@ -4,13 +4,18 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	Port         string
-	DBConnString string
+	Port             string
+	DBConnString     string
+	ProductRetention time.Duration
+	PurgeInterval    time.Duration
+	PriceInterval    time.Duration
 }
 
 func LoadConfig() *Config {
@@ -20,8 +25,11 @@ func LoadConfig() *Config {
 	}
 
 	return &Config{
-		Port:         getEnv("PORT", "50052"),
-		DBConnString: getDBConnString(),
+		Port:             getEnv("PORT", "50052"),
+		DBConnString:     getDBConnString(),
+		ProductRetention: time.Duration(getEnvInt("PRODUCT_RETENTION_DAYS", 90)) * 24 * time.Hour,
+		PurgeInterval:    time.Duration(getEnvInt("PURGE_INTERVAL_HOURS", 24)) * time.Hour,
+		PriceInterval:    time.Duration(getEnvInt("PRICE_SCHEDULE_INTERVAL_MINUTES", 1)) * time.Minute,
 	}
 }
 
@@ -43,3 +51,11 @@ func getEnv(key, defaultValue string) string {
 	}
 	return value
 }
+
+func getEnvInt(key string, defaultValue int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return defaultValue
+	}
+	return value
+}
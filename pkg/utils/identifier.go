@@ -0,0 +1,104 @@
+package utils
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/PharmaKart/product-svc/pkg/errors"
+)
+
+var (
+	digitsPattern = regexp.MustCompile(`^[0-9]+$`)
+
+	// NDC segment layouts (labeler-product-package) and the padding needed to reach 5-4-2
+	ndcLayouts = map[[3]int][3]int{
+		{4, 4, 2}: {1, 0, 0},
+		{5, 3, 2}: {0, 1, 0},
+		{5, 4, 1}: {0, 0, 1},
+		{5, 4, 2}: {0, 0, 0},
+	}
+)
+
+// NormalizeIdentifier validates a product identifier and returns its canonical type and value.
+// "gtin", "upc" and "ean" codes are checked against their check digit and returned as a GTIN-14,
+// "ndc" codes are returned in the 11-digit 5-4-2 form and "din" codes must be 8 digits.
+func NormalizeIdentifier(identifierType string, value string) (string, string, error) {
+	identifierType = strings.ToLower(strings.TrimSpace(identifierType))
+	value = strings.TrimSpace(value)
+
+	switch identifierType {
+	case "gtin", "upc", "ean":
+		lengths := map[string][]int{
+			"gtin": {8, 12, 13, 14},
+			"upc":  {12},
+			"ean":  {8, 13},
+		}[identifierType]
+
+		if !digitsPattern.MatchString(value) || !slices.Contains(lengths, len(value)) {
+			return "", "", errors.NewValidationError("value", "Invalid "+strings.ToUpper(identifierType)+" length or format")
+		}
+
+		if !validGTINCheckDigit(value) {
+			return "", "", errors.NewValidationError("value", "Invalid "+strings.ToUpper(identifierType)+" check digit")
+		}
+
+		return "gtin", strings.Repeat("0", 14-len(value)) + value, nil
+	case "ndc":
+		normalized, ok := normalizeNDC(value)
+		if !ok {
+			return "", "", errors.NewValidationError("value", "NDC must be hyphenated as 4-4-2, 5-3-2 or 5-4-1, or be 11 digits")
+		}
+		return "ndc", normalized, nil
+	case "din":
+		if !digitsPattern.MatchString(value) || len(value) != 8 {
+			return "", "", errors.NewValidationError("value", "DIN must be 8 digits")
+		}
+		return "din", value, nil
+	default:
+		return "", "", errors.NewValidationError("type", "Invalid identifier type")
+	}
+}
+
+// validGTINCheckDigit verifies the GS1 mod-10 check digit of a GTIN-8/12/13/14
+func validGTINCheckDigit(code string) bool {
+	sum := 0
+	for i := len(code) - 2; i >= 0; i-- {
+		digit := int(code[i] - '0')
+		if (len(code)-2-i)%2 == 0 {
+			digit *= 3
+		}
+		sum += digit
+	}
+	return (10-sum%10)%10 == int(code[len(code)-1]-'0')
+}
+
+// normalizeNDC converts a hyphenated 10-digit NDC, or an 11-digit NDC, to the 11-digit 5-4-2 form
+func normalizeNDC(value string) (string, bool) {
+	segments := strings.Split(value, "-")
+	if len(segments) == 1 {
+		if digitsPattern.MatchString(value) && len(value) == 11 {
+			return value, true
+		}
+		return "", false
+	}
+
+	if len(segments) != 3 {
+		return "", false
+	}
+
+	layout := [3]int{len(segments[0]), len(segments[1]), len(segments[2])}
+	padding, ok := ndcLayouts[layout]
+	if !ok {
+		return "", false
+	}
+
+	var normalized strings.Builder
+	for i, segment := range segments {
+		if !digitsPattern.MatchString(segment) {
+			return "", false
+		}
+		normalized.WriteString(strings.Repeat("0", padding[i]) + segment)
+	}
+	return normalized.String(), true
+}
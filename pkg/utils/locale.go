@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+)
+
+var localePattern = regexp.MustCompile(`^[a-z]{2}(-[A-Z]{2})?$`)
+
+// NormalizeLocale formats a locale as a lowercase language with an optional uppercase region, e.g. "fr-CA"
+func NormalizeLocale(locale string) string {
+	locale = strings.ReplaceAll(strings.TrimSpace(locale), "_", "-")
+	language, region, found := strings.Cut(locale, "-")
+	if !found {
+		return strings.ToLower(language)
+	}
+	return strings.ToLower(language) + "-" + strings.ToUpper(region)
+}
+
+// IsValidLocale checks that a normalized locale is a language with an optional region
+func IsValidLocale(locale string) bool {
+	return localePattern.MatchString(locale)
+}
+
+// GetLocale returns the locale requested by the caller, taken from the request when set or else from the
+// "locale" or "accept-language" gRPC metadata. It returns an empty string when no valid locale was given.
+func GetLocale(ctx context.Context, requested string) string {
+	candidates := []string{requested}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		candidates = append(candidates, md.Get("locale")...)
+		for _, header := range md.Get("accept-language") {
+			// Only the preferred language of the header is used, e.g. "fr-CA" in "fr-CA,fr;q=0.9,en;q=0.8"
+			tag, _, _ := strings.Cut(header, ",")
+			tag, _, _ = strings.Cut(tag, ";")
+			candidates = append(candidates, tag)
+		}
+	}
+
+	for _, candidate := range candidates {
+		if locale := NormalizeLocale(candidate); IsValidLocale(locale) {
+			return locale
+		}
+	}
+	return ""
+}
+
+// LocaleFallbacks lists the locales to try for a locale, most specific first: "fr-CA" falls back to "fr".
+// The product's own content is the last fallback.
+func LocaleFallbacks(locale string) []string {
+	if locale == "" {
+		return nil
+	}
+
+	if language, _, found := strings.Cut(locale, "-"); found {
+		return []string{locale, language}
+	}
+	return []string{locale}
+}
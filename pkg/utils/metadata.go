@@ -0,0 +1,36 @@
+package utils
+
+import (
+	"context"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// IsAdmin reports whether the caller was authenticated as an admin, based on the role the gateway forwards in the gRPC metadata
+func IsAdmin(ctx context.Context) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+
+	for _, role := range md.Get("role") {
+		if role == "admin" {
+			return true
+		}
+	}
+	return false
+}
+
+// GetActor returns the ID of the user making the request, as forwarded by the gateway in the gRPC metadata.
+// Requests without a user come from other services and are attributed to "system".
+func GetActor(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "system"
+	}
+
+	if userIDs := md.Get("user_id"); len(userIDs) > 0 && userIDs[0] != "" {
+		return userIDs[0]
+	}
+	return "system"
+}
@@ -0,0 +1,18 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+var regionPattern = regexp.MustCompile(`^[A-Z]{2}(-[A-Z0-9]{1,3})?$`)
+
+// NormalizeRegion formats a region code in uppercase, e.g. "ca_on" becomes "CA-ON"
+func NormalizeRegion(region string) string {
+	return strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(region), "_", "-"))
+}
+
+// IsValidRegion checks that a normalized region is an ISO 3166 country code, optionally with a subdivision
+func IsValidRegion(region string) bool {
+	return regionPattern.MatchString(region)
+}
@@ -0,0 +1,32 @@
+package utils
+
+import "strings"
+
+const maxSlugLength = 80
+
+// Slugify turns a product name into a lowercase URL slug of letters, digits and single hyphens,
+// e.g. "Ibuprofen 200mg" becomes "ibuprofen-200mg"
+func Slugify(name string) string {
+	var builder strings.Builder
+	hyphen := false
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			builder.WriteRune(r)
+			hyphen = false
+		} else if !hyphen && builder.Len() > 0 {
+			builder.WriteByte('-')
+			hyphen = true
+		}
+	}
+
+	slug := builder.String()
+	if len(slug) > maxSlugLength {
+		slug = slug[:maxSlugLength]
+	}
+
+	slug = strings.Trim(slug, "-")
+	if slug == "" {
+		return "product"
+	}
+	return slug
+}
@@ -0,0 +1,28 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Ibuprofen 200mg", "ibuprofen-200mg"},
+		{"  Vitamin D3 -- 1000 IU  ", "vitamin-d3-1000-iu"},
+		{"Acetaminophen/Codeine (Tylenol #3)", "acetaminophen-codeine-tylenol-3"},
+		{"Crème Hydratante", "cr-me-hydratante"},
+		{"---", "product"},
+		{"", "product"},
+		{strings.Repeat("a", 79) + " b", strings.Repeat("a", 79)},
+		{strings.Repeat("a", 100), strings.Repeat("a", 80)},
+	}
+
+	for _, tt := range tests {
+		if got := Slugify(tt.name); got != tt.want {
+			t.Errorf("Slugify(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -56,6 +56,11 @@ func GetModelColumns(model interface{}) map[string]bool {
 		tag := field.Tag.Get("gorm")
 		columnName := ""
 
+		// Skip associations and ignored fields, they are not columns of this table
+		if tag == "-" || strings.Contains(tag, "foreignKey:") || strings.Contains(tag, "many2many:") {
+			continue
+		}
+
 		// Parse the gorm tag to find column name
 		tagParts := strings.Split(tag, ";")
 		for _, part := range tagParts {
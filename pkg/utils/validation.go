@@ -1,11 +1,14 @@
 package utils
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/PharmaKart/product-svc/internal/models"
 	"github.com/PharmaKart/product-svc/pkg/errors"
+	"github.com/google/uuid"
 )
 
 func ValidateProductInput(product *models.Product) error {
@@ -18,23 +21,79 @@ func ValidateProductInput(product *models.Product) error {
 		validationErrors["description"] = "Description is required"
 	}
 
-	if product.Price <= 0 {
-		validationErrors["price"] = "Price must be greater than 0"
+	if err := validatePrice(product.Price, product.PriceMinor, product.Currency); err != "" {
+		validationErrors["price"] = err
+	}
+
+	if _, ok := models.CurrencyExponents[product.Currency]; !ok {
+		validationErrors["currency"] = "Currency must be one of CAD, USD, EUR, GBP, JPY"
 	}
 
 	if product.Stock < 0 {
 		validationErrors["stock"] = "Stock must be greater than or equal to 0"
 	}
 
-	if product.ImageURL != nil {
-		trimmedURL := strings.TrimSpace(*product.ImageURL)
-		s3Pattern := `^https://[^.]+\.s3\.[^.]+\.amazonaws\.com/`
-		matched, err := regexp.MatchString(s3Pattern, trimmedURL)
-		if err != nil || !matched {
+	for _, image := range product.Images {
+		if !IsS3ImageURL(image.URL) {
 			validationErrors["image"] = "Invalid S3 image URL"
 		}
 	}
 
+	if _, ok := models.ProductStatusTransitions[product.Status]; !ok {
+		validationErrors["status"] = "Status must be one of draft, active, discontinued, recalled"
+	}
+
+	if !models.PrescriptionClasses[product.PrescriptionClass] {
+		validationErrors["prescriptionClass"] = "Prescription class must be one of otc, pharmacy_only, prescription, controlled"
+	} else if product.PrescriptionClass == models.PrescriptionClassControlled {
+		if product.ControlledSchedule == nil || !models.ControlledSchedules[*product.ControlledSchedule] {
+			validationErrors["controlledSchedule"] = "Controlled products require a schedule of I, II, III, IV or V"
+		}
+	} else if product.ControlledSchedule != nil {
+		validationErrors["controlledSchedule"] = "Only controlled products have a schedule"
+	}
+
+	if product.CostMinor != nil && *product.CostMinor <= 0 {
+		validationErrors["costPrice"] = "Cost price must be greater than 0"
+	} else if product.CostMinor != nil && product.CostCurrency != "" && product.CostCurrency != product.Currency {
+		validationErrors["costPrice"] = fmt.Sprintf("Cost price must be in %s", product.Currency)
+	}
+
+	if product.TaxCategory != nil && !models.TaxCategories[*product.TaxCategory] {
+		validationErrors["taxCategory"] = "Tax category must be one of zero_rated, reduced, standard"
+	}
+
+	if product.DosageForm != nil && !models.DosageForms[*product.DosageForm] {
+		validationErrors["dosageForm"] = "Invalid dosage form"
+	}
+
+	if (product.Strength == nil) != (product.StrengthUnit == nil) {
+		validationErrors["strength"] = "Strength and strength unit must be set together"
+	} else if product.Strength != nil {
+		if *product.Strength <= 0 {
+			validationErrors["strength"] = "Strength must be greater than 0"
+		}
+		if !models.StrengthUnits[*product.StrengthUnit] {
+			validationErrors["strengthUnit"] = "Invalid strength unit"
+		}
+	}
+
+	if product.Route != nil && !models.Routes[*product.Route] {
+		validationErrors["route"] = "Invalid route of administration"
+	}
+
+	if product.StorageCondition != nil {
+		if !models.StorageConditions[*product.StorageCondition] {
+			validationErrors["storageCondition"] = "Storage condition must be one of room_temperature, refrigerated, frozen"
+		} else if *product.StorageCondition != "room_temperature" && !product.RequiresColdChain {
+			validationErrors["requiresColdChain"] = "Refrigerated and frozen products require cold-chain shipping"
+		}
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.NewValidationErrors(validationErrors)
+	}
+
 	return nil
 }
 
@@ -46,3 +105,424 @@ func ValidateInventoryInput(inventory *models.InventoryLog) error {
 
 	return nil
 }
+
+// ValidateVariantInput validates a variant, priced in the currency of its product
+func ValidateVariantInput(variant *models.ProductVariant, currency string) error {
+	validationErrors := make(map[string]string)
+	if variant.ProductID == uuid.Nil {
+		validationErrors["productId"] = "Product ID is required"
+	}
+
+	if matched, _ := regexp.MatchString(`^[A-Za-z0-9][A-Za-z0-9._-]{0,63}$`, variant.SKU); !matched {
+		validationErrors["sku"] = "SKU must be 1-64 letters, digits, '.', '_' or '-'"
+	}
+
+	if strings.TrimSpace(variant.Name) == "" {
+		validationErrors["name"] = "Name is required"
+	}
+
+	if err := validatePrice(variant.Price, variant.PriceMinor, currency); err != "" {
+		validationErrors["price"] = err
+	}
+
+	if variant.Stock < 0 {
+		validationErrors["stock"] = "Stock must be greater than or equal to 0"
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.NewValidationErrors(validationErrors)
+	}
+
+	return nil
+}
+
+func ValidateManufacturerInput(manufacturer *models.Manufacturer) error {
+	validationErrors := make(map[string]string)
+	if strings.TrimSpace(manufacturer.Name) == "" {
+		validationErrors["name"] = "Name is required"
+	}
+
+	if manufacturer.Website != nil && *manufacturer.Website != "" {
+		if matched, _ := regexp.MatchString(`^https?://[^\s/$.?#].[^\s]*$`, strings.TrimSpace(*manufacturer.Website)); !matched {
+			validationErrors["website"] = "Invalid website URL"
+		}
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.NewValidationErrors(validationErrors)
+	}
+
+	return nil
+}
+
+func ValidateBrandInput(brand *models.Brand) error {
+	validationErrors := make(map[string]string)
+	if strings.TrimSpace(brand.Name) == "" {
+		validationErrors["name"] = "Name is required"
+	}
+
+	if brand.ManufacturerID == uuid.Nil {
+		validationErrors["manufacturerId"] = "Manufacturer ID is required"
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.NewValidationErrors(validationErrors)
+	}
+
+	return nil
+}
+
+func ValidateIngredientInput(ingredient *models.ProductIngredient) error {
+	validationErrors := make(map[string]string)
+	if ingredient.Ingredient == nil || strings.TrimSpace(ingredient.Ingredient.Name) == "" {
+		validationErrors["ingredientName"] = "Ingredient name is required"
+	}
+
+	if ingredient.Strength <= 0 {
+		validationErrors["strength"] = "Strength must be greater than 0"
+	}
+
+	if !models.StrengthUnits[ingredient.StrengthUnit] {
+		validationErrors["strengthUnit"] = "Strength unit must be one of mg, g, mcg, ml, mg/ml, mg/g, %, iu, iu/ml"
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.NewValidationErrors(validationErrors)
+	}
+
+	return nil
+}
+
+func ValidateImageInput(image *models.ProductImage) error {
+	validationErrors := make(map[string]string)
+	if image.ProductID == uuid.Nil {
+		validationErrors["productId"] = "Product ID is required"
+	}
+
+	if !IsS3ImageURL(image.URL) {
+		validationErrors["url"] = "Invalid S3 image URL"
+	}
+
+	if image.AltText != nil && len(*image.AltText) > 255 {
+		validationErrors["altText"] = "Alt text must be at most 255 characters"
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.NewValidationErrors(validationErrors)
+	}
+
+	return nil
+}
+
+func ValidateRecallInput(recall *models.Recall) error {
+	validationErrors := make(map[string]string)
+	if recall.ProductID == uuid.Nil {
+		validationErrors["productId"] = "Product ID is required"
+	}
+
+	if strings.TrimSpace(recall.Reason) == "" {
+		validationErrors["reason"] = "Reason is required"
+	}
+
+	if !models.RecallSeverityClasses[recall.SeverityClass] {
+		validationErrors["severityClass"] = "Severity class must be one of class_i, class_ii, class_iii"
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.NewValidationErrors(validationErrors)
+	}
+
+	return nil
+}
+
+func ValidateTranslationInput(translation *models.ProductTranslation) error {
+	validationErrors := make(map[string]string)
+	if translation.ProductID == uuid.Nil {
+		validationErrors["productId"] = "Product ID is required"
+	}
+
+	if !IsValidLocale(translation.Locale) {
+		validationErrors["locale"] = "Locale must be a language code with an optional region, e.g. fr or fr-CA"
+	}
+
+	if strings.TrimSpace(translation.Name) == "" {
+		validationErrors["name"] = "Name is required"
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.NewValidationErrors(validationErrors)
+	}
+
+	return nil
+}
+
+func ValidateRelationInput(relation *models.ProductRelation) error {
+	validationErrors := make(map[string]string)
+	if relation.ProductID == uuid.Nil {
+		validationErrors["productId"] = "Product ID is required"
+	}
+
+	if relation.RelatedProductID == uuid.Nil {
+		validationErrors["relatedProductId"] = "Related product ID is required"
+	} else if relation.RelatedProductID == relation.ProductID {
+		validationErrors["relatedProductId"] = "A product cannot be related to itself"
+	}
+
+	if !models.RelationTypes[relation.Type] {
+		validationErrors["type"] = "Type must be one of accessory, frequently_bought_together, alternative, replacement"
+	}
+
+	if relation.SortOrder < 0 {
+		validationErrors["sortOrder"] = "Sort order cannot be negative"
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.NewValidationErrors(validationErrors)
+	}
+
+	return nil
+}
+
+func ValidateRegionRuleInput(rule *models.ProductRegionRule) error {
+	validationErrors := make(map[string]string)
+	if rule.ProductID == uuid.Nil {
+		validationErrors["productId"] = "Product ID is required"
+	}
+
+	if !IsValidRegion(rule.Region) {
+		validationErrors["region"] = "Region must be an ISO 3166 country code with an optional subdivision, e.g. CA or CA-ON"
+	}
+
+	if !models.RegionRules[rule.Rule] {
+		validationErrors["rule"] = "Rule must be one of not_sold, otc, prescription, quantity_limited"
+	} else if rule.Rule == models.RegionRuleQuantityLimited {
+		if rule.MaxQuantity == nil || *rule.MaxQuantity <= 0 {
+			validationErrors["maxQuantity"] = "Quantity-limited rules require a maximum quantity greater than 0"
+		}
+	} else if rule.MaxQuantity != nil {
+		validationErrors["maxQuantity"] = "Only quantity-limited rules have a maximum quantity"
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.NewValidationErrors(validationErrors)
+	}
+
+	return nil
+}
+
+func ValidatePriceListInput(priceList *models.PriceList) error {
+	validationErrors := make(map[string]string)
+	if strings.TrimSpace(priceList.Name) == "" {
+		validationErrors["name"] = "Name is required"
+	}
+
+	if _, ok := models.CurrencyExponents[priceList.Currency]; !ok {
+		validationErrors["currency"] = "Currency must be one of CAD, USD, EUR, GBP, JPY"
+	}
+
+	if !models.SalesChannels[priceList.Channel] {
+		validationErrors["channel"] = "Channel must be one of web, in_store, b2b_clinic"
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.NewValidationErrors(validationErrors)
+	}
+
+	return nil
+}
+
+func ValidatePriceListItemInput(item *models.PriceListItem) error {
+	validationErrors := make(map[string]string)
+	if item.ProductID == uuid.Nil {
+		validationErrors["productId"] = "Product ID is required"
+	}
+
+	if item.PriceMinor <= 0 {
+		validationErrors["price"] = "Price must be greater than 0"
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.NewValidationErrors(validationErrors)
+	}
+
+	return nil
+}
+
+// ValidatePriceTiersInput checks tiers sorted by minimum quantity, larger quantities cannot cost more per unit
+func ValidatePriceTiersInput(tiers []models.PriceTier) error {
+	validationErrors := make(map[string]string)
+	for i, tier := range tiers {
+		field := fmt.Sprintf("tiers[%d]", i)
+
+		if tier.MinQuantity <= 0 {
+			validationErrors[field] = "Minimum quantity must be greater than 0"
+			continue
+		}
+
+		if tier.PriceMinor <= 0 {
+			validationErrors[field] = "Price must be greater than 0"
+			continue
+		}
+
+		if i > 0 && tier.MinQuantity == tiers[i-1].MinQuantity {
+			validationErrors[field] = "Minimum quantity is listed more than once"
+			continue
+		}
+
+		if i > 0 && tier.PriceMinor > tiers[i-1].PriceMinor {
+			validationErrors[field] = "Price cannot be higher than the price of a smaller quantity"
+		}
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.NewValidationErrors(validationErrors)
+	}
+
+	return nil
+}
+
+func ValidatePriceChangeInput(change *models.PriceChange, now time.Time) error {
+	validationErrors := make(map[string]string)
+	if change.ProductID == uuid.Nil {
+		validationErrors["productId"] = "Product ID is required"
+	}
+
+	if change.PriceMinor <= 0 {
+		validationErrors["price"] = "Price must be greater than 0"
+	}
+
+	if !change.EffectiveAt.After(now) {
+		validationErrors["effectiveAt"] = "Scheduled price changes must take effect in the future"
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.NewValidationErrors(validationErrors)
+	}
+
+	return nil
+}
+
+func ValidateTaxRateInput(rate *models.TaxRate) error {
+	validationErrors := make(map[string]string)
+	if !IsValidRegion(rate.Region) {
+		validationErrors["region"] = "Region must be an ISO 3166 country code with an optional subdivision, e.g. CA or CA-ON"
+	}
+
+	if rate.TaxCategory == models.TaxCategoryZeroRated {
+		validationErrors["taxCategory"] = "Zero-rated products are not taxed"
+	} else if !models.TaxCategories[rate.TaxCategory] {
+		validationErrors["taxCategory"] = "Tax category must be one of reduced, standard"
+	}
+
+	if rate.RateMilliPercent < 0 || rate.RateMilliPercent > 100000 {
+		validationErrors["rate"] = "Rate must be between 0 and 100%"
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.NewValidationErrors(validationErrors)
+	}
+
+	return nil
+}
+
+func ValidateMarginRuleInput(rule *models.MarginRule) error {
+	validationErrors := make(map[string]string)
+	if !models.MarginRuleTargetTypes[rule.TargetType] {
+		validationErrors["targetType"] = "Target type must be one of all, brand, product"
+	} else if rule.TargetType == models.MarginRuleTargetAll && rule.TargetID != nil {
+		validationErrors["targetId"] = "Rules for all products have no target ID"
+	} else if rule.TargetType != models.MarginRuleTargetAll && rule.TargetID == nil {
+		validationErrors["targetId"] = "Target ID is required"
+	}
+
+	if rule.MinMarginBasisPoints < 0 || rule.MinMarginBasisPoints >= 10000 {
+		validationErrors["minMargin"] = "Minimum margin must be at least 0 and below 100%"
+	}
+
+	if !models.MarginEnforcements[rule.Enforcement] {
+		validationErrors["enforcement"] = "Enforcement must be one of reject, override"
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.NewValidationErrors(validationErrors)
+	}
+
+	return nil
+}
+
+func ValidatePromotionInput(promotion *models.Promotion) error {
+	validationErrors := make(map[string]string)
+	if promotion.Name == "" {
+		validationErrors["name"] = "Name is required"
+	}
+
+	switch promotion.Type {
+	case models.PromotionPercentage:
+		if promotion.PercentOff == nil || *promotion.PercentOff < 1 || *promotion.PercentOff > 100 {
+			validationErrors["percentOff"] = "Percentage promotions require a percentage between 1 and 100"
+		}
+	case models.PromotionFixed:
+		if promotion.AmountOffMinor == nil || *promotion.AmountOffMinor <= 0 {
+			validationErrors["amountOff"] = "Fixed promotions require an amount off greater than 0"
+		}
+		if _, ok := models.CurrencyExponents[stringValue(promotion.Currency)]; !ok {
+			validationErrors["currency"] = "Currency must be one of CAD, USD, EUR, GBP, JPY"
+		}
+	case models.PromotionBuyXGetY:
+		if promotion.BuyQuantity == nil || *promotion.BuyQuantity <= 0 || promotion.GetQuantity == nil || *promotion.GetQuantity <= 0 {
+			validationErrors["buyQuantity"] = "Buy X get Y promotions require buy and get quantities greater than 0"
+		}
+	default:
+		validationErrors["type"] = "Type must be one of percentage, fixed, buy_x_get_y"
+	}
+
+	if promotion.StartsAt.IsZero() || !promotion.EndsAt.After(promotion.StartsAt) {
+		validationErrors["endsAt"] = "Promotions must end after they start"
+	}
+
+	if len(promotion.Targets) == 0 {
+		validationErrors["targets"] = "At least one target is required"
+	}
+
+	for _, target := range promotion.Targets {
+		if !models.PromotionTargetTypes[target.TargetType] {
+			validationErrors["targets"] = "Target type must be one of product, brand"
+		} else if target.TargetID == uuid.Nil {
+			validationErrors["targets"] = "Target ID is required"
+		}
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.NewValidationErrors(validationErrors)
+	}
+
+	return nil
+}
+
+// stringValue returns the value of an optional string, or an empty string when it is unset
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+// validatePrice checks a price given in minor units, or in the deprecated decimal field when it has no
+// minor units, in which case it may not be more precise than the minor unit of the currency
+func validatePrice(price float64, priceMinor int64, currency string) string {
+	if priceMinor < 0 || (priceMinor == 0 && price <= 0) {
+		return "Price must be greater than 0"
+	}
+
+	if exponent, ok := models.CurrencyExponents[currency]; ok && priceMinor == 0 && !models.HasCurrencyPrecision(price, currency) {
+		return fmt.Sprintf("Price in %s must have at most %d decimals", currency, exponent)
+	}
+	return ""
+}
+
+// IsS3ImageURL checks that an image URL points to an S3 bucket
+func IsS3ImageURL(url string) bool {
+	s3Pattern := `^https://[^.]+\.s3\.[^.]+\.amazonaws\.com/`
+	matched, err := regexp.MatchString(s3Pattern, strings.TrimSpace(url))
+	return err == nil && matched
+}